@@ -0,0 +1,103 @@
+package sastrawi
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// TokenFilter modifies tokens that produced by tokenizer, e.g. removing stop words or
+// stemming words. The tokens might be modified in place.
+type TokenFilter interface {
+	Filter(tokens []Token) []Token
+}
+
+// TokenFilterFunc is function that used as TokenFilter
+type TokenFilterFunc func(tokens []Token) []Token
+
+// Filter calls fn(tokens)
+func (fn TokenFilterFunc) Filter(tokens []Token) []Token {
+	return fn(tokens)
+}
+
+// WordFilterFunc returns TokenFilter that replaces the text of each word token with
+// fn(text). If fn returns empty string, the token will be removed. Other token types,
+// e.g. URL and number, are kept as it is.
+func WordFilterFunc(fn func(word string) string) TokenFilter {
+	return TokenFilterFunc(func(tokens []Token) []Token {
+		result := tokens[:0]
+		for _, token := range tokens {
+			if token.Type == TokenWord {
+				token.Text = fn(token.Text)
+				if token.Text == "" {
+					continue
+				}
+			}
+
+			result = append(result, token)
+		}
+
+		return result
+	})
+}
+
+// LowercaseFilter returns TokenFilter that converts all tokens to lower case
+func LowercaseFilter() TokenFilter {
+	return TokenFilterFunc(func(tokens []Token) []Token {
+		for i := range tokens {
+			tokens[i].Text = strings.ToLower(tokens[i].Text)
+		}
+
+		return tokens
+	})
+}
+
+// NormalizeFilter returns TokenFilter that converts informal words into their standard
+// form. If the standard form consists of several words, each of them becomes a token
+// with the same offsets and position as the original token.
+func NormalizeFilter(normalizer Normalizer) TokenFilter {
+	return TokenFilterFunc(func(tokens []Token) []Token {
+		result := make([]Token, 0, len(tokens))
+		for _, token := range tokens {
+			if token.Type != TokenWord {
+				result = append(result, token)
+				continue
+			}
+
+			for _, word := range strings.Fields(normalizer.Normalize(token.Text)) {
+				token.Text = word
+				result = append(result, token)
+			}
+		}
+
+		return result
+	})
+}
+
+// StopwordFilter returns TokenFilter that removes word tokens found in stopwords
+func StopwordFilter(stopwords RootLookup) TokenFilter {
+	return WordFilterFunc(func(word string) string {
+		if stopwords.Contains(word) {
+			return ""
+		}
+
+		return word
+	})
+}
+
+// StemFilter returns TokenFilter that replaces word tokens with their root using stem,
+// e.g. the Stem method of Stemmer or CachedStemmer
+func StemFilter(stem func(word string) string) TokenFilter {
+	return WordFilterFunc(stem)
+}
+
+// MinLengthFilter returns TokenFilter that removes word tokens which have less than
+// minLength characters
+func MinLengthFilter(minLength int) TokenFilter {
+	return WordFilterFunc(func(word string) string {
+		if utf8.RuneCountInString(word) < minLength {
+			return ""
+		}
+
+		return word
+	})
+}
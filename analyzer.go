@@ -0,0 +1,189 @@
+package sastrawi
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Analyzer is pipeline that splits text into tokens using its tokenizer,
+// then passes the tokens through its filters in order
+type Analyzer struct {
+	Tokenizer Tokenizer
+	Filters   []TokenFilter
+}
+
+// Analyze splits text into tokens, then filters them
+func (analyzer Analyzer) Analyze(text string) []Token {
+	tokens := analyzer.Tokenizer.TokenizeWithOffsets(text)
+	for _, filter := range analyzer.Filters {
+		tokens = filter.Filter(tokens)
+	}
+
+	return tokens
+}
+
+// Words is like Analyze, but only returns the text of each token
+func (analyzer Analyzer) Words(text string) []string {
+	tokens := analyzer.Analyze(text)
+	words := make([]string, len(tokens))
+	for i, token := range tokens {
+		words[i] = token.Text
+	}
+
+	return words
+}
+
+var (
+	defaultFilters     defaultFilterSet
+	defaultFiltersOnce sync.Once
+)
+
+// defaultFilterSet is the filters of the default analyzer
+type defaultFilterSet struct {
+	normalize TokenFilter
+	stopword  TokenFilter
+	stem      TokenFilter
+}
+
+// DefaultAnalyzer returns Analyzer that normalizes informal words, removes stop words
+// and stems the remaining words, using the default lexicon, stop words and dictionary.
+// Its tokenizer keeps numbers, so words like "s4y4" reach the normalizer intact. The
+// filters are created once and shared, so it's cheap to call DefaultAnalyzer many
+// times. The filters are safe to be used by multiple goroutines.
+func DefaultAnalyzer() Analyzer {
+	filters := sharedFilters()
+	return Analyzer{
+		Tokenizer: Tokenizer{KeepNumbers: true},
+		Filters:   []TokenFilter{filters.normalize, filters.stopword, filters.stem},
+	}
+}
+
+// sharedFilters returns the filters of the default analyzer, which also used by
+// the registered filters with the same name
+func sharedFilters() defaultFilterSet {
+	defaultFiltersOnce.Do(func() {
+		defaultFilters = defaultFilterSet{
+			normalize: NormalizeFilter(NewNormalizer()),
+			stopword:  StopwordFilter(DefaultStopword()),
+			stem:      StemFilter(NewCachedStemmer(NewStemmer(DefaultDictionary()), 10000).Stem),
+		}
+	})
+
+	return defaultFilters
+}
+
+var (
+	registryMutex sync.RWMutex
+	tokenFilters  = map[string]func() TokenFilter{}
+	analyzers     = map[string]func() Analyzer{}
+)
+
+func init() {
+	RegisterTokenFilter("lowercase", LowercaseFilter)
+	RegisterTokenFilter("normalize", func() TokenFilter {
+		return sharedFilters().normalize
+	})
+	RegisterTokenFilter("stopword", func() TokenFilter {
+		return sharedFilters().stopword
+	})
+	RegisterTokenFilter("stem", func() TokenFilter {
+		return sharedFilters().stem
+	})
+	RegisterTokenFilter("min_length", func() TokenFilter {
+		return MinLengthFilter(2)
+	})
+
+	RegisterAnalyzer("sastrawi", DefaultAnalyzer)
+	RegisterAnalyzer("simple", func() Analyzer {
+		return Analyzer{}
+	})
+}
+
+// RegisterTokenFilter makes token filter available by name, so it can be created using
+// NewTokenFilter. The factory is called each time the filter is created. It panics if
+// factory is nil or name is already registered.
+func RegisterTokenFilter(name string, factory func() TokenFilter) {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+
+	if factory == nil {
+		panic("sastrawi: token filter factory is nil")
+	}
+
+	if _, exist := tokenFilters[name]; exist {
+		panic("sastrawi: token filter " + name + " is already registered")
+	}
+
+	tokenFilters[name] = factory
+}
+
+// NewTokenFilter creates token filter that registered with name
+func NewTokenFilter(name string) (TokenFilter, error) {
+	registryMutex.RLock()
+	factory, exist := tokenFilters[name]
+	registryMutex.RUnlock()
+
+	if !exist {
+		return nil, fmt.Errorf("unknown token filter %q", name)
+	}
+
+	return factory(), nil
+}
+
+// TokenFilterNames returns names of all registered token filters, sorted alphabetically
+func TokenFilterNames() []string {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+
+	return sortedKeys(tokenFilters)
+}
+
+// RegisterAnalyzer makes analyzer available by name, so it can be created using
+// NewAnalyzer. The factory is called each time the analyzer is created. It panics if
+// factory is nil or name is already registered.
+func RegisterAnalyzer(name string, factory func() Analyzer) {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+
+	if factory == nil {
+		panic("sastrawi: analyzer factory is nil")
+	}
+
+	if _, exist := analyzers[name]; exist {
+		panic("sastrawi: analyzer " + name + " is already registered")
+	}
+
+	analyzers[name] = factory
+}
+
+// NewAnalyzer creates analyzer that registered with name
+func NewAnalyzer(name string) (Analyzer, error) {
+	registryMutex.RLock()
+	factory, exist := analyzers[name]
+	registryMutex.RUnlock()
+
+	if !exist {
+		return Analyzer{}, fmt.Errorf("unknown analyzer %q", name)
+	}
+
+	return factory(), nil
+}
+
+// AnalyzerNames returns names of all registered analyzers, sorted alphabetically
+func AnalyzerNames() []string {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+
+	return sortedKeys(analyzers)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
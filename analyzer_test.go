@@ -0,0 +1,125 @@
+package sastrawi
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAnalyzer(t *testing.T) {
+	text := "Aku gak bisa pulang krn harus membacakan buku2 yg sgt membosankan bangeeet http://t.co/x"
+
+	// The default analyzer replaces the README loop
+	analyzer := DefaultAnalyzer()
+	tokens := analyzer.Analyze(text)
+	result := []string{}
+	positions := []int{}
+	for _, token := range tokens {
+		result = append(result, token.Text)
+		positions = append(positions, token.Position)
+	}
+
+	expected := []string{"pulang", "baca", "buku", "sgt", "bosan", "banget"}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected: %q\nresult:   %q", expected, result)
+	}
+
+	if expected := []int{3, 6, 7, 9, 10, 11}; !reflect.DeepEqual(positions, expected) {
+		t.Errorf("expected positions: %v, result: %v", expected, positions)
+	}
+
+	for _, token := range tokens {
+		if !strings.EqualFold(text[token.Start:token.End], token.Surface) {
+			t.Errorf("%q: surface %q doesn't match offsets", token.Text, token.Surface)
+		}
+	}
+
+	// Custom pipeline keeps URL, and only stems words
+	analyzer = Analyzer{
+		Tokenizer: Tokenizer{KeepCase: true, KeepURLs: true},
+		Filters: []TokenFilter{
+			LowercaseFilter(),
+			StopwordFilter(NewDictionary("yg", "aku")),
+			MinLengthFilter(4),
+			StemFilter(NewStemmer(DefaultDictionary()).Stem),
+		},
+	}
+
+	expected = []string{"bisa", "pulang", "harus", "baca", "buku", "bosan", "bangeeet", "http://t.co/x"}
+	if result := analyzer.Words(text); !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected: %q\nresult:   %q", expected, result)
+	}
+
+	// Words written with digits are normalized instead of being dropped by the tokenizer
+	expected = []string{"suka", "ajar", "matematika", "2020"}
+	if result := DefaultAnalyzer().Words("s4y4 gak suka b3l4j4r m4t3m4tik4 2020"); !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected: %q\nresult:   %q", expected, result)
+	}
+
+	analyzer = DefaultAnalyzer()
+	analyzer.Filters = analyzer.Filters[:1]
+	expected = []string{"saya", "tidak", "suka"}
+	if result := analyzer.Words("s4y4 gak suka"); !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected: %q\nresult:   %q", expected, result)
+	}
+
+	// Modifying the filters doesn't affect the default analyzer
+	analyzer = DefaultAnalyzer()
+	analyzer.Filters[0] = LowercaseFilter()
+	if result := DefaultAnalyzer().Words("yg"); len(result) != 0 {
+		t.Errorf("expected no words, result: %q", result)
+	}
+}
+
+func TestAnalyzerRegistry(t *testing.T) {
+	RegisterTokenFilter("test_reverse", func() TokenFilter {
+		return WordFilterFunc(func(word string) string {
+			runes := []rune(word)
+			for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+				runes[i], runes[j] = runes[j], runes[i]
+			}
+
+			return string(runes)
+		})
+	})
+
+	filter, err := NewTokenFilter("test_reverse")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	RegisterAnalyzer("test_reverse", func() Analyzer {
+		return Analyzer{Filters: []TokenFilter{filter}}
+	})
+
+	analyzer, err := NewAnalyzer("test_reverse")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result := analyzer.Words("Kata baru"); !reflect.DeepEqual(result, []string{"atak", "urab"}) {
+		t.Errorf("expected: %q, result: %q", []string{"atak", "urab"}, result)
+	}
+
+	if _, err := NewTokenFilter("unknown"); err == nil {
+		t.Error("expected error for unknown token filter")
+	}
+
+	if _, err := NewAnalyzer("unknown"); err == nil {
+		t.Error("expected error for unknown analyzer")
+	}
+
+	for _, name := range []string{"lowercase", "normalize", "stopword", "stem", "min_length"} {
+		if _, err := NewTokenFilter(name); err != nil {
+			t.Error(err)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic when registering duplicate analyzer")
+		}
+	}()
+
+	RegisterAnalyzer("sastrawi", DefaultAnalyzer)
+}
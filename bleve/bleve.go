@@ -0,0 +1,262 @@
+// Package bleve registers Sastrawi's tokenizer, token filters and analyzer into the
+// registry of Bleve, so they can be used in index mapping by their name. Import it for
+// its side effect :
+//
+//	import _ "github.com/RadhiFadlillah/go-sastrawi/bleve"
+//
+//	indexMapping := bleve.NewIndexMapping()
+//	indexMapping.DefaultAnalyzer = "sastrawi"
+//
+// The "sastrawi" analyzer produces the same terms as sastrawi.DefaultAnalyzer, i.e. it
+// normalizes informal words, removes stop words, then stems the remaining words.
+package bleve
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/RadhiFadlillah/go-sastrawi"
+	"github.com/blevesearch/bleve/v2/analysis"
+	"github.com/blevesearch/bleve/v2/registry"
+)
+
+// Names of the registered components
+const (
+	AnalyzerName  = "sastrawi"
+	TokenizerName = "sastrawi"
+	NormalizeName = "normalize_id"
+	StopName      = "stop_id"
+	StemmerName   = "stemmer_id_sastrawi"
+)
+
+func init() {
+	registrations := []error{
+		registry.RegisterTokenizer(TokenizerName, TokenizerConstructor),
+		registry.RegisterTokenFilter(NormalizeName, NormalizeFilterConstructor),
+		registry.RegisterTokenFilter(StopName, StopFilterConstructor),
+		registry.RegisterTokenFilter(StemmerName, StemFilterConstructor),
+		registry.RegisterAnalyzer(AnalyzerName, AnalyzerConstructor),
+	}
+
+	for _, err := range registrations {
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Tokenizer is Bleve tokenizer that uses sastrawi.Tokenizer
+type Tokenizer struct {
+	tokenizer sastrawi.Tokenizer
+}
+
+// NewTokenizer returns Bleve tokenizer that splits text using tokenizer
+func NewTokenizer(tokenizer sastrawi.Tokenizer) *Tokenizer {
+	return &Tokenizer{tokenizer: tokenizer}
+}
+
+// Tokenize splits input into tokens. Words and numbers are typed as analysis.AlphaNumeric
+// and analysis.Numeric, while the others, e.g. URL and email, as analysis.Single.
+func (tokenizer *Tokenizer) Tokenize(input []byte) analysis.TokenStream {
+	tokens := tokenizer.tokenizer.TokenizeWithOffsets(string(input))
+	stream := make(analysis.TokenStream, len(tokens))
+	for i, token := range tokens {
+		tokenType := analysis.Single
+		switch token.Type {
+		case sastrawi.TokenWord:
+			tokenType = analysis.AlphaNumeric
+		case sastrawi.TokenNumber:
+			tokenType = analysis.Numeric
+		}
+
+		stream[i] = &analysis.Token{
+			Start:    token.Start,
+			End:      token.End,
+			Term:     []byte(token.Text),
+			Position: token.Position + 1,
+			Type:     tokenType,
+		}
+	}
+
+	return stream
+}
+
+// TokenizerConstructor creates Tokenizer. By default it's the same as the tokenizer of
+// sastrawi.DefaultAnalyzer, whose options can be changed from config using snake case
+// keys, e.g. "keep_numbers" and "fold_diacritics".
+func TokenizerConstructor(config map[string]interface{}, cache *registry.Cache) (analysis.Tokenizer, error) {
+	tokenizer := sastrawi.DefaultAnalyzer().Tokenizer
+	options := map[string]*bool{
+		"keep_numbers":    &tokenizer.KeepNumbers,
+		"keep_hashtags":   &tokenizer.KeepHashtags,
+		"keep_mentions":   &tokenizer.KeepMentions,
+		"keep_hyphens":    &tokenizer.KeepHyphens,
+		"keep_urls":       &tokenizer.KeepURLs,
+		"keep_emails":     &tokenizer.KeepEmails,
+		"keep_case":       &tokenizer.KeepCase,
+		"unicode":         &tokenizer.Unicode,
+		"fold_diacritics": &tokenizer.FoldDiacritics,
+	}
+
+	for key, value := range config {
+		option, exist := options[key]
+		if !exist {
+			continue
+		}
+
+		enabled, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("%s must be boolean", key)
+		}
+
+		*option = enabled
+	}
+
+	return NewTokenizer(tokenizer), nil
+}
+
+// StemFilter is Bleve token filter that replaces words with their root
+type StemFilter struct {
+	stem func(word string) string
+}
+
+// NewStemFilter returns StemFilter that uses stem, e.g. the Stem method of CachedStemmer
+func NewStemFilter(stem func(word string) string) *StemFilter {
+	return &StemFilter{stem: stem}
+}
+
+// Filter stems the words in input, except the ones marked as keyword
+func (filter *StemFilter) Filter(input analysis.TokenStream) analysis.TokenStream {
+	for _, token := range input {
+		if token.Type == analysis.AlphaNumeric && !token.KeyWord {
+			token.Term = []byte(filter.stem(string(token.Term)))
+		}
+	}
+
+	return input
+}
+
+// defaultStemmer is shared by all stem filters that use the default dictionary
+var defaultStemmer = sync.OnceValue(func() *sastrawi.CachedStemmer {
+	return sastrawi.NewCachedStemmer(sastrawi.NewStemmer(sastrawi.DefaultDictionary()), 10000)
+})
+
+// StemFilterConstructor creates StemFilter. By default it uses the default dictionary,
+// which can be replaced with dictionary file from "dictionary" in config.
+func StemFilterConstructor(config map[string]interface{}, cache *registry.Cache) (analysis.TokenFilter, error) {
+	path, _ := config["dictionary"].(string)
+	if path == "" {
+		return NewStemFilter(defaultStemmer().Stem), nil
+	}
+
+	dict, err := sastrawi.LoadDictionaryFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	stemmer := sastrawi.NewCachedStemmer(sastrawi.NewStemmer(dict), 10000)
+	return NewStemFilter(stemmer.Stem), nil
+}
+
+// StopFilter is Bleve token filter that removes stop words
+type StopFilter struct {
+	stopwords sastrawi.RootLookup
+}
+
+// NewStopFilter returns StopFilter that removes words found in stopwords
+func NewStopFilter(stopwords sastrawi.RootLookup) *StopFilter {
+	return &StopFilter{stopwords: stopwords}
+}
+
+// Filter removes stop words from input
+func (filter *StopFilter) Filter(input analysis.TokenStream) analysis.TokenStream {
+	result := input[:0]
+	for _, token := range input {
+		if token.Type != analysis.AlphaNumeric || !filter.stopwords.Contains(string(token.Term)) {
+			result = append(result, token)
+		}
+	}
+
+	return result
+}
+
+// StopFilterConstructor creates StopFilter. By default it uses sastrawi.DefaultStopword,
+// which can be replaced with list of words from "stopwords" in config.
+func StopFilterConstructor(config map[string]interface{}, cache *registry.Cache) (analysis.TokenFilter, error) {
+	words, exist := config["stopwords"].([]interface{})
+	if !exist {
+		return NewStopFilter(sastrawi.DefaultStopword()), nil
+	}
+
+	stopwords := sastrawi.NewDictionary()
+	for _, word := range words {
+		str, ok := word.(string)
+		if !ok {
+			return nil, fmt.Errorf("stopwords must be list of string")
+		}
+
+		stopwords.Add(str)
+	}
+
+	return NewStopFilter(stopwords), nil
+}
+
+// NormalizeFilter is Bleve token filter that converts informal words into their standard form
+type NormalizeFilter struct {
+	normalizer sastrawi.Normalizer
+}
+
+// NewNormalizeFilter returns NormalizeFilter that uses normalizer
+func NewNormalizeFilter(normalizer sastrawi.Normalizer) *NormalizeFilter {
+	return &NormalizeFilter{normalizer: normalizer}
+}
+
+// Filter normalizes words in input. If the standard form consists of several words,
+// each of them becomes a token with the same offsets and position as the original.
+func (filter *NormalizeFilter) Filter(input analysis.TokenStream) analysis.TokenStream {
+	result := make(analysis.TokenStream, 0, len(input))
+	for _, token := range input {
+		if token.Type != analysis.AlphaNumeric || token.KeyWord {
+			result = append(result, token)
+			continue
+		}
+
+		for _, word := range strings.Fields(filter.normalizer.Normalize(string(token.Term))) {
+			normalized := *token
+			normalized.Term = []byte(word)
+			result = append(result, &normalized)
+		}
+	}
+
+	return result
+}
+
+// NormalizeFilterConstructor creates NormalizeFilter that uses the default lexicon
+func NormalizeFilterConstructor(config map[string]interface{}, cache *registry.Cache) (analysis.TokenFilter, error) {
+	return NewNormalizeFilter(sastrawi.NewNormalizer()), nil
+}
+
+// AnalyzerConstructor creates analyzer that normalizes informal words, removes stop words,
+// then stems the remaining words
+func AnalyzerConstructor(config map[string]interface{}, cache *registry.Cache) (analysis.Analyzer, error) {
+	tokenizer, err := cache.TokenizerNamed(TokenizerName)
+	if err != nil {
+		return nil, err
+	}
+
+	filters := []analysis.TokenFilter{}
+	for _, name := range []string{NormalizeName, StopName, StemmerName} {
+		filter, err := cache.TokenFilterNamed(name)
+		if err != nil {
+			return nil, err
+		}
+
+		filters = append(filters, filter)
+	}
+
+	return &analysis.DefaultAnalyzer{
+		Tokenizer:    tokenizer,
+		TokenFilters: filters,
+	}, nil
+}
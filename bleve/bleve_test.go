@@ -0,0 +1,128 @@
+package bleve_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/RadhiFadlillah/go-sastrawi"
+	sastrawibleve "github.com/RadhiFadlillah/go-sastrawi/bleve"
+	"github.com/blevesearch/bleve/v2"
+	_ "github.com/blevesearch/bleve/v2/analysis/analyzer/custom"
+)
+
+func TestAnalyzer(t *testing.T) {
+	indexMapping := bleve.NewIndexMapping()
+	text := "Yg bikin aku bangga, perekonomian Indonesia sdh tumbuh pesat sejak 2020 krn b3l4j4r"
+
+	tokens, err := indexMapping.AnalyzeText(sastrawibleve.AnalyzerName, []byte(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The terms and positions are the same as the default analyzer of Sastrawi
+	expected := sastrawi.DefaultAnalyzer().Analyze(text)
+	if len(tokens) != len(expected) {
+		t.Fatalf("expected %d tokens, result: %v", len(expected), tokens)
+	}
+
+	for i, token := range tokens {
+		if string(token.Term) != expected[i].Text || token.Position != expected[i].Position+1 ||
+			token.Start != expected[i].Start || token.End != expected[i].End {
+			t.Errorf("expected: %+v, result: %v", expected[i], token)
+		}
+	}
+}
+
+func TestIndex(t *testing.T) {
+	indexMapping := bleve.NewIndexMapping()
+	indexMapping.DefaultAnalyzer = sastrawibleve.AnalyzerName
+
+	index, err := bleve.NewMemOnly(indexMapping)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer index.Close()
+
+	documents := map[string]string{
+		"1": "Perekonomian Indonesia sedang dalam pertumbuhan yang membanggakan",
+		"2": "Rakyat memenuhi halaman gedung untuk menyuarakan isi hatinya",
+		"3": "Mereka sdh menyuarakan kebanggaan atas pertumbuhan ekonomi",
+	}
+
+	for id, text := range documents {
+		if err := index.Index(id, map[string]string{"text": text}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testItems := []struct {
+		query    string
+		expected []string
+	}{
+		{"bangga", []string{"1", "3"}},
+		{"ekonomi", []string{"1", "3"}},
+		{"suara", []string{"2", "3"}},
+		{"kebanggaan rakyat", []string{"1", "2", "3"}},
+		{"sudah", []string{}},
+	}
+
+	for _, item := range testItems {
+		request := bleve.NewSearchRequest(bleve.NewMatchQuery(item.query))
+		request.SortBy([]string{"_id"})
+		result, err := index.Search(request)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ids := []string{}
+		for _, hit := range result.Hits {
+			ids = append(ids, hit.ID)
+		}
+
+		if !reflect.DeepEqual(ids, item.expected) {
+			t.Errorf("%s, expected: %v, result: %v", item.query, item.expected, ids)
+		}
+	}
+}
+
+func TestCustomComponents(t *testing.T) {
+	indexMapping := bleve.NewIndexMapping()
+	err := indexMapping.AddCustomTokenFilter("stop_custom", map[string]interface{}{
+		"type":      sastrawibleve.StopName,
+		"stopwords": []interface{}{"rakyat"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = indexMapping.AddCustomTokenizer("sastrawi_numbers", map[string]interface{}{
+		"type":         sastrawibleve.TokenizerName,
+		"keep_numbers": true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = indexMapping.AddCustomAnalyzer("custom", map[string]interface{}{
+		"type":          "custom",
+		"tokenizer":     "sastrawi_numbers",
+		"token_filters": []interface{}{"stop_custom", sastrawibleve.StemmerName},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tokens, err := indexMapping.AnalyzeText("custom", []byte("Rakyat memenuhi 2020 halaman"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	terms := []string{}
+	for _, token := range tokens {
+		terms = append(terms, string(token.Term))
+	}
+
+	if expected := []string{"penuh", "2020", "halaman"}; !reflect.DeepEqual(terms, expected) {
+		t.Errorf("expected: %q, result: %q", expected, terms)
+	}
+}
@@ -0,0 +1,550 @@
+// Command sastrawi stems, tokenizes and analyzes Indonesian text from arguments or
+// standard input.
+//
+// Usage:
+//
+//	sastrawi stem [flags] [word ...]
+//	sastrawi tokenize [flags] [text ...]
+//	sastrawi analyze [flags] [text ...]
+//	sastrawi dict check|add|list [flags] [word ...]
+//	sastrawi serve [flags]
+//
+// When no word or text given as arguments, it's read from standard input. The
+// output format is chosen using -format, which can be text, tsv or json (one
+// JSON object per line).
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/RadhiFadlillah/go-sastrawi"
+	"github.com/RadhiFadlillah/go-sastrawi/server"
+)
+
+const usage = `Usage:
+  sastrawi stem [flags] [word ...]       reduce words to their root
+  sastrawi tokenize [flags] [text ...]   split text into tokens
+  sastrawi analyze [flags] [text ...]    tokenize, remove stop words then stem
+  sastrawi dict check [flags] word ...   check if words exist in dictionary
+  sastrawi dict add -dict file word ...  add words to dictionary file
+  sastrawi dict list [flags]             list words in dictionary
+  sastrawi serve [flags]                 serve JSON API over HTTP
+
+Words and text are read from standard input when not given as arguments.
+Run "sastrawi <command> -h" to see the flags of each command.
+`
+
+// errUsage is returned when command is called with invalid arguments
+var errUsage = errors.New("invalid usage")
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes command in args, and returns the exit code
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+
+	var cmd func([]string, io.Reader, *output) error
+	switch args[0] {
+	case "stem":
+		cmd = runStem
+	case "tokenize":
+		cmd = runTokenize
+	case "analyze":
+		cmd = runAnalyze
+	case "dict":
+		cmd = runDict
+	case "serve":
+		cmd = runServe
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(stdout, usage)
+		return 0
+	default:
+		fmt.Fprintf(stderr, "sastrawi: unknown command %q\n\n%s", args[0], usage)
+		return 2
+	}
+
+	bw := bufio.NewWriter(stdout)
+	out := &output{w: bw, stderr: stderr}
+	err := cmd(args[1:], stdin, out)
+	if flushErr := bw.Flush(); err == nil {
+		err = flushErr
+	}
+
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.Is(err, errUsage):
+		return 2
+	default:
+		fmt.Fprintf(stderr, "sastrawi %s: %v\n", args[0], err)
+		return 1
+	}
+}
+
+// options is the flags that shared by commands
+type options struct {
+	flags     *flag.FlagSet
+	format    string
+	dict      string
+	stopwords string
+	normalize bool
+	tokenizer *sastrawi.Tokenizer
+}
+
+func newOptions(name string, out *output) *options {
+	opts := &options{flags: flag.NewFlagSet("sastrawi "+name, flag.ContinueOnError)}
+	opts.flags.SetOutput(out.stderr)
+	opts.flags.StringVar(&opts.dict, "dict", "", "root words dictionary file (default is the built-in dictionary)")
+	return opts
+}
+
+// formatFlag registers -format flag for commands that write output
+func (opts *options) formatFlag() *options {
+	opts.flags.StringVar(&opts.format, "format", "text", "output format: text, tsv or json")
+	return opts
+}
+
+// analyzerFlags registers flags for building analyzer
+func (opts *options) analyzerFlags() *options {
+	opts.flags.StringVar(&opts.stopwords, "stopwords", "", "stop words file (default is the built-in stop words)")
+	opts.flags.BoolVar(&opts.normalize, "normalize", false, "normalize informal words before removing stop words")
+	opts.tokenizer = tokenizerFlags(opts.flags)
+	return opts
+}
+
+func (opts *options) parse(args []string, out *output) error {
+	if err := opts.flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+
+		return errUsage
+	}
+
+	switch opts.format {
+	case "", "text", "tsv", "json":
+		out.format = opts.format
+		return nil
+	default:
+		fmt.Fprintf(out.stderr, "invalid format %q\n", opts.format)
+		return errUsage
+	}
+}
+
+func (opts *options) dictionary() (*sastrawi.DictionaryView, error) {
+	if opts.dict == "" {
+		return sastrawi.DefaultDictionary(), nil
+	}
+
+	dict, err := sastrawi.LoadDictionaryFile(opts.dict)
+	if err != nil {
+		return nil, err
+	}
+
+	return sastrawi.NewDictionaryView(dict), nil
+}
+
+// analyzer builds analyzer that removes stop words then stems the words, and returns
+// it along with the stemmer it uses
+func (opts *options) analyzer() (sastrawi.Analyzer, *sastrawi.CachedStemmer, error) {
+	dict, err := opts.dictionary()
+	if err != nil {
+		return sastrawi.Analyzer{}, nil, err
+	}
+
+	stopwords := sastrawi.DefaultStopword()
+	if opts.stopwords != "" {
+		if stopwords, err = sastrawi.LoadDictionaryFile(opts.stopwords); err != nil {
+			return sastrawi.Analyzer{}, nil, err
+		}
+	}
+
+	filters := []sastrawi.TokenFilter{}
+	if opts.normalize {
+		normalizer := sastrawi.NewNormalizer()
+		normalizer.Dictionary = dict
+		filters = append(filters, sastrawi.NormalizeFilter(normalizer))
+	}
+
+	stemmer := sastrawi.NewCachedStemmer(sastrawi.NewStemmer(dict), 10000)
+	filters = append(filters,
+		sastrawi.StopwordFilter(stopwords),
+		sastrawi.StemFilter(stemmer.Stem))
+
+	return sastrawi.Analyzer{Tokenizer: *opts.tokenizer, Filters: filters}, stemmer, nil
+}
+
+// input returns the text in args joined by space, or the text from stdin
+func input(args []string, stdin io.Reader) io.Reader {
+	if len(args) > 0 {
+		return strings.NewReader(strings.Join(args, " "))
+	}
+
+	return stdin
+}
+
+// eachWord calls fn for each word in args, or each line of stdin
+func eachWord(args []string, stdin io.Reader, fn func(word string) error) error {
+	if len(args) > 0 {
+		for _, word := range args {
+			if err := fn(word); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	scanner := bufio.NewScanner(stdin)
+	for scanner.Scan() {
+		word := strings.TrimSpace(scanner.Text())
+		if word == "" {
+			continue
+		}
+
+		if err := fn(word); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+func runStem(args []string, stdin io.Reader, out *output) error {
+	opts := newOptions("stem", out).formatFlag()
+	if err := opts.parse(args, out); err != nil {
+		return err
+	}
+
+	dict, err := opts.dictionary()
+	if err != nil {
+		return err
+	}
+
+	stemmer := sastrawi.NewCachedStemmer(sastrawi.NewStemmer(dict), 10000)
+	return eachWord(opts.flags.Args(), stdin, func(word string) error {
+		word = strings.ToLower(word)
+		root := stemmer.Stem(word)
+		return out.write(word+" => "+root, []string{word, root}, map[string]string{
+			"word": word,
+			"root": root,
+		})
+	})
+}
+
+func runTokenize(args []string, stdin io.Reader, out *output) error {
+	opts := newOptions("tokenize", out).formatFlag()
+	tokenizer := tokenizerFlags(opts.flags)
+	if err := opts.parse(args, out); err != nil {
+		return err
+	}
+
+	scanner := tokenizer.NewScanner(input(opts.flags.Args(), stdin))
+	for scanner.Scan() {
+		if err := out.writeToken(scanner.Token()); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+func runAnalyze(args []string, stdin io.Reader, out *output) error {
+	opts := newOptions("analyze", out).formatFlag().analyzerFlags()
+	if err := opts.parse(args, out); err != nil {
+		return err
+	}
+
+	analyzer, _, err := opts.analyzer()
+	if err != nil {
+		return err
+	}
+
+	// Filter each token as soon as it's scanned, so the text doesn't have to fit in memory
+	scanner := analyzer.Tokenizer.NewScanner(input(opts.flags.Args(), stdin))
+	for scanner.Scan() {
+		tokens := []sastrawi.Token{scanner.Token()}
+		for _, filter := range analyzer.Filters {
+			tokens = filter.Filter(tokens)
+		}
+
+		for _, token := range tokens {
+			if err := out.writeToken(token); err != nil {
+				return err
+			}
+		}
+	}
+
+	return scanner.Err()
+}
+
+func runDict(args []string, stdin io.Reader, out *output) error {
+	if len(args) == 0 {
+		fmt.Fprint(out.stderr, "sastrawi dict: missing subcommand check, add or list\n")
+		return errUsage
+	}
+
+	opts := newOptions("dict "+args[0], out).formatFlag()
+	if err := opts.parse(args[1:], out); err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "check":
+		dict, err := opts.dictionary()
+		if err != nil {
+			return err
+		}
+
+		return eachWord(opts.flags.Args(), stdin, func(word string) error {
+			word = strings.ToLower(word)
+			found := dict.Contains(word)
+			text := word + ": not found"
+			if found {
+				text = word + ": found"
+			}
+
+			return out.write(text, []string{word, strconv.FormatBool(found)}, map[string]any{
+				"word":  word,
+				"found": found,
+			})
+		})
+
+	case "list":
+		dict, err := opts.dictionary()
+		if err != nil {
+			return err
+		}
+
+		for _, word := range dict.Words() {
+			if err := out.write(word, []string{word}, map[string]string{"word": word}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+
+	case "add":
+		if opts.dict == "" {
+			fmt.Fprint(out.stderr, "sastrawi dict add: -dict is required\n")
+			return errUsage
+		}
+
+		// The new words are appended to the existing lines, so comments
+		// and blank lines in the file are kept as they are
+		content, mode, err := readDictionaryFile(opts.dict)
+		if err != nil {
+			return err
+		}
+
+		dict, err := sastrawi.LoadDictionary(bytes.NewReader(content))
+		if err != nil {
+			return fmt.Errorf("%s: %w", opts.dict, err)
+		}
+
+		added := 0
+		err = eachWord(opts.flags.Args(), stdin, func(word string) error {
+			word = strings.ToLower(word)
+			if err := sastrawi.ValidateWord(word); err != nil {
+				return err
+			}
+
+			if word == "" || dict.Contains(word) {
+				return nil
+			}
+
+			if len(content) > 0 && content[len(content)-1] != '\n' {
+				content = append(content, '\n')
+			}
+
+			content = append(content, word+"\n"...)
+			dict.Add(word)
+			added++
+			return nil
+		})
+
+		if err != nil {
+			return err
+		}
+
+		if added > 0 {
+			if err := writeDictionaryFile(opts.dict, content, mode); err != nil {
+				return err
+			}
+		}
+
+		fmt.Fprintf(out.stderr, "%d words added to %s\n", added, opts.dict)
+		return nil
+
+	default:
+		fmt.Fprintf(out.stderr, "sastrawi dict: unknown subcommand %q\n", args[0])
+		return errUsage
+	}
+}
+
+func runServe(args []string, stdin io.Reader, out *output) error {
+	opts := newOptions("serve", out).analyzerFlags()
+	addr := opts.flags.String("addr", "localhost:8080", "address to listen on")
+	maxBody := opts.flags.Int64("max-body", server.DefaultMaxBodySize, "maximum size of request body in bytes")
+	maxBatch := opts.flags.Int("max-batch", server.DefaultMaxBatchSize, "maximum number of items in batch request")
+	if err := opts.parse(args, out); err != nil {
+		return err
+	}
+
+	analyzer, stemmer, err := opts.analyzer()
+	if err != nil {
+		return err
+	}
+
+	handler := server.NewHandler(stemmer.Stem, analyzer)
+	handler.MaxBodySize = *maxBody
+	handler.MaxBatchSize = *maxBatch
+
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       time.Minute,
+		WriteTimeout:      time.Minute,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- srv.ListenAndServe()
+	}()
+
+	fmt.Fprintf(out.stderr, "listening on %s\n", *addr)
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	return srv.Shutdown(shutdownCtx)
+}
+
+// readDictionaryFile returns the content and permission of dictionary file in path.
+// If the file doesn't exist yet, it returns empty content.
+func readDictionaryFile(path string) ([]byte, os.FileMode, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, 0o644, nil
+	}
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if len(content) >= 2 && content[0] == 0x1f && content[1] == 0x8b {
+		return nil, 0, fmt.Errorf("%s: can't add words to compressed dictionary", path)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return content, info.Mode().Perm(), nil
+}
+
+// writeDictionaryFile writes content into temporary file with the same permission,
+// then renames it to path, so the dictionary is never left half written
+func writeDictionaryFile(path string, content []byte, mode os.FileMode) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	_, err = f.Write(content)
+	if err == nil {
+		err = f.Chmod(mode)
+	}
+
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
+
+// tokenizerFlags registers the tokenizer's options as flags
+func tokenizerFlags(flags *flag.FlagSet) *sastrawi.Tokenizer {
+	tokenizer := &sastrawi.Tokenizer{}
+	flags.BoolVar(&tokenizer.KeepNumbers, "numbers", false, "keep numbers")
+	flags.BoolVar(&tokenizer.KeepHyphens, "hyphens", false, "keep hyphenated words together")
+	flags.BoolVar(&tokenizer.KeepURLs, "urls", false, "keep URLs")
+	flags.BoolVar(&tokenizer.KeepEmails, "emails", false, "keep email addresses")
+	flags.BoolVar(&tokenizer.KeepHashtags, "hashtags", false, "keep hashtags")
+	flags.BoolVar(&tokenizer.KeepMentions, "mentions", false, "keep mentions")
+	flags.BoolVar(&tokenizer.Unicode, "unicode", false, "treat letters of every script as word characters")
+	flags.BoolVar(&tokenizer.FoldDiacritics, "fold", false, "convert letters with diacritic into ASCII")
+	flags.BoolVar(&tokenizer.Classify, "classify", false, "keep every part of text as typed token")
+	return tokenizer
+}
+
+// output writes result in the chosen format
+type output struct {
+	w      *bufio.Writer
+	stderr io.Writer
+	format string
+}
+
+// write writes text, tsv columns or JSON value depending on the format
+func (out *output) write(text string, columns []string, value any) error {
+	switch out.format {
+	case "tsv":
+		_, err := fmt.Fprintln(out.w, strings.Join(columns, "\t"))
+		return err
+	case "json":
+		return json.NewEncoder(out.w).Encode(value)
+	default:
+		_, err := fmt.Fprintln(out.w, text)
+		return err
+	}
+}
+
+func (out *output) writeToken(token sastrawi.Token) error {
+	columns := []string{
+		token.Text,
+		token.Type.String(),
+		strings.NewReplacer("\t", " ", "\n", " ").Replace(token.Surface),
+		strconv.Itoa(token.Start),
+		strconv.Itoa(token.End),
+		strconv.Itoa(token.Position),
+	}
+
+	return out.write(token.Text, columns, token)
+}
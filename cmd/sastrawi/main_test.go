@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	dictPath := filepath.Join(dir, "dict.txt")
+	if err := os.WriteFile(dictPath, []byte("# kamus uji\nmakan\n\nminum"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	testItems := []struct {
+		args     []string
+		stdin    string
+		expected string
+		code     int
+	}{
+		{[]string{"stem", "menahan", "Pewarna"}, "", "menahan => tahan\npewarna => warna\n", 0},
+		{[]string{"stem", "-format", "tsv"}, "menahan\n\nmakanan\n", "menahan\ttahan\nmakanan\tmakan\n", 0},
+		{[]string{"stem", "-format", "json", "-dict", dictPath, "makanan"}, "", `{"root":"makan","word":"makanan"}` + "\n", 0},
+		{[]string{"tokenize"}, "Rakyat memenuhi\nhalaman &amp; gedung", "rakyat\nmemenuhi\nhalaman\ngedung\n", 0},
+		{[]string{"tokenize", "-format", "tsv", "-urls", "Baca", "http://kompas.com"}, "",
+			"baca\tWORD\tBaca\t0\t4\t0\nhttp://kompas.com\tURL\thttp://kompas.com\t5\t22\t1\n", 0},
+		{[]string{"analyze", "-format", "json"}, "Perekonomian yang membanggakan",
+			`{"text":"ekonomi","type":"WORD","surface":"Perekonomian","start":0,"end":12,"start_rune":0,"end_rune":12,"position":0}` + "\n" +
+				`{"text":"bangga","type":"WORD","surface":"membanggakan","start":18,"end":30,"start_rune":18,"end_rune":30,"position":2}` + "\n", 0},
+		{[]string{"analyze", "-normalize", "yg", "dimakan"}, "", "makan\n", 0},
+		{[]string{"dict", "check", "-dict", dictPath, "Makan", "tidur"}, "", "makan: found\ntidur: not found\n", 0},
+		{[]string{"dict", "add", "-dict", dictPath, "Tidur", "makan"}, "", "", 0},
+		{[]string{"dict", "add", "-dict", dictPath}, "minum air\n", "", 1},
+		{[]string{"dict", "add", "-dict", dictPath, "mandi!"}, "", "", 1},
+		{[]string{"dict", "list", "-dict", dictPath}, "", "makan\nminum\ntidur\n", 0},
+		{[]string{"dict", "add", "tidur"}, "", "", 2},
+		{[]string{"stem", "-format", "xml"}, "", "", 2},
+		{[]string{"stem", "-dict", filepath.Join(dir, "missing.txt"), "makan"}, "", "", 1},
+		{[]string{"serve", "-format", "json"}, "", "", 2},
+		{[]string{"serve", "-dict", filepath.Join(dir, "missing.txt")}, "", "", 1},
+		{[]string{"unknown"}, "", "", 2},
+		{nil, "", "", 2},
+	}
+
+	for _, item := range testItems {
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+		code := run(item.args, strings.NewReader(item.stdin), stdout, stderr)
+		if code != item.code {
+			t.Errorf("%q, expected exit code %d, result: %d (%s)", item.args, item.code, code, stderr)
+		}
+
+		if result := stdout.String(); result != item.expected {
+			t.Errorf("%q\nexpected: %q\nresult:   %q", item.args, item.expected, result)
+		}
+	}
+
+	// Adding words keeps the comments, blank lines and permission of dictionary file
+	content, err := os.ReadFile(dictPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := "# kamus uji\nmakan\n\nminum\ntidur\n"; string(content) != expected {
+		t.Errorf("dict add, expected file: %q, result: %q", expected, content)
+	}
+
+	info, err := os.Stat(dictPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("dict add, expected mode 0600, result: %v", info.Mode().Perm())
+	}
+}
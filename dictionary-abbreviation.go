@@ -0,0 +1,17 @@
+package sastrawi
+
+// DefaultAbbreviation is default database of abbreviations in Indonesian language that
+// written with trailing period, e.g. "dr." and "dll.". The words are in lower case and
+// without the period. Abbreviation that only consists of single letters, e.g. "S.H." and
+// "a.n.", doesn't have to be listed since initials are never treated as end of sentence.
+func DefaultAbbreviation() Dictionary {
+	return NewDictionary(
+		"adm", "ag", "akbp", "almh", "alm", "ayt", "bd", "bhd", "bpk", "brigjen", "cv", "dik", "dkk",
+		"dll", "dr", "dra", "drg", "drh", "drs", "dsb", "dst", "dtk", "gg", "hj", "hlm", "hal", "hum",
+		"inf", "ir", "irjen", "jend", "jl", "jln", "kab", "kapt", "kec", "kel", "kes", "kol", "kom",
+		"kombes", "kompol", "komjen", "kpd", "letjen", "letkol", "lih", "mayjen", "mayor", "md", "min",
+		"mis", "mr", "mrs", "ms", "msi", "mt", "nip", "nim", "nn", "no", "ny", "pd", "pol", "prof",
+		"psi", "pt", "rp", "sc", "sdr", "sdri", "sos", "sp", "st", "tbk", "tel", "telp", "tgl", "th",
+		"thn", "tn", "ybs", "yth",
+	)
+}
@@ -0,0 +1,34 @@
+package sastrawi
+
+import "testing"
+
+func TestDefaultDictionary(t *testing.T) {
+	dict := DefaultDictionary()
+	if dict.Count() != 29932 {
+		t.Errorf("expected 29932 words, result: %d", dict.Count())
+	}
+
+	for _, word := range []string{"aba", "abal-abal", "kupu-kupu", "untung", "zuriah"} {
+		if !dict.Contains(word) {
+			t.Errorf("expected default dictionary to contain %s", word)
+		}
+	}
+
+	// Modifying the returned dictionary doesn't affect the default dictionary
+	dict.Remove("aba")
+	if dict.Contains("aba") || !DefaultDictionary().Contains("aba") {
+		t.Errorf("expected removing word from a view doesn't affect the default dictionary")
+	}
+
+	// The words are shared, so it doesn't copy them
+	if n := testing.AllocsPerRun(10, func() { DefaultDictionary() }); n > 1 {
+		t.Errorf("expected at most 1 allocation, result: %v", n)
+	}
+}
+
+func BenchmarkDefaultDictionary(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		DefaultDictionary()
+	}
+}
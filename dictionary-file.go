@@ -0,0 +1,117 @@
+package sastrawi
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// DictionaryError is the error that returned when dictionary file contains malformed entry
+type DictionaryError struct {
+	// Line is the line number of the entry, or its position
+	// when dictionary decoded from JSON array
+	Line   int
+	Word   string
+	Reason string
+}
+
+func (err *DictionaryError) Error() string {
+	return fmt.Sprintf("line %d: %q %s", err.Line, err.Word, err.Reason)
+}
+
+// LoadDictionary creates new Dictionary from r, which contains one word per line.
+// Blank lines and text after # are ignored. If r is compressed using gzip, it will
+// be decompressed automatically.
+func LoadDictionary(r io.Reader) (Dictionary, error) {
+	br := bufio.NewReader(r)
+	if magic, _ := br.Peek(2); len(magic) == 2 && magic[0] == 0x1f && magic[1] == 0x8b {
+		gr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		defer gr.Close()
+
+		br = bufio.NewReader(gr)
+	}
+
+	dict := NewDictionary()
+	scanner := bufio.NewScanner(br)
+	for line := 1; scanner.Scan(); line++ {
+		word := scanner.Text()
+		if line == 1 {
+			word = strings.TrimPrefix(word, "\ufeff")
+		}
+
+		if idx := strings.IndexByte(word, '#'); idx >= 0 {
+			word = word[:idx]
+		}
+
+		word = strings.TrimSpace(word)
+		if word == "" {
+			continue
+		}
+
+		if reason := validateWord(word); reason != "" {
+			return nil, &DictionaryError{Line: line, Word: word, Reason: reason}
+		}
+
+		dict[word] = struct{}{}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return dict, nil
+}
+
+// LoadDictionaryFile creates new Dictionary from file in path. See LoadDictionary for the file format.
+func LoadDictionaryFile(path string) (Dictionary, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	dict, err := LoadDictionary(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return dict, nil
+}
+
+// ValidateWord checks if word can be used as dictionary entry, i.e. it's written in
+// lower case, and only contains letters and hyphens that not at its start or end
+func ValidateWord(word string) error {
+	if reason := validateWord(word); reason != "" {
+		return fmt.Errorf("%q %s", word, reason)
+	}
+
+	return nil
+}
+
+// validateWord returns the reason why word is not a valid dictionary entry,
+// or empty string if it's valid
+func validateWord(word string) string {
+	if strings.HasPrefix(word, "-") || strings.HasSuffix(word, "-") {
+		return "starts or ends with hyphen"
+	}
+
+	for _, r := range word {
+		switch {
+		case unicode.IsSpace(r):
+			return "contains whitespace"
+		case unicode.IsUpper(r) || unicode.IsTitle(r):
+			return "contains uppercase letter"
+		case r != '-' && !unicode.IsLetter(r):
+			return fmt.Sprintf("contains invalid character %q", r)
+		}
+	}
+
+	return ""
+}
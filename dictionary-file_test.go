@@ -0,0 +1,99 @@
+package sastrawi
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadDictionary(t *testing.T) {
+	content := "\ufeff# Root words\n\nmakan\n  minum  \r\nkupu-kupu # reduplication\n"
+
+	buffer := bytes.Buffer{}
+	gw := gzip.NewWriter(&buffer)
+	gw.Write([]byte(content))
+	gw.Close()
+
+	for name, input := range map[string]string{"plain": content, "gzip": buffer.String()} {
+		dict, err := LoadDictionary(strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		if dict.Count() != 3 || !dict.Contains("makan") || !dict.Contains("minum") || !dict.Contains("kupu-kupu") {
+			t.Errorf("%s, expected: makan, minum, kupu-kupu, result: %v", name, dict)
+		}
+	}
+}
+
+func TestLoadDictionaryError(t *testing.T) {
+	testItems := []struct {
+		content string
+		line    int
+	}{
+		{content: "makan\nMinum\n", line: 2},
+		{content: "makan\n\nminum air\n", line: 3},
+		{content: "makan2\n", line: 1},
+		{content: "# comment\n-nya\n", line: 2},
+	}
+
+	for _, item := range testItems {
+		_, err := LoadDictionary(strings.NewReader(item.content))
+
+		var dictErr *DictionaryError
+		if !errors.As(err, &dictErr) || dictErr.Line != item.line {
+			t.Errorf("%q, expected error in line %d, result: %v", item.content, item.line, err)
+		}
+	}
+}
+
+func TestValidateWord(t *testing.T) {
+	for _, word := range []string{"makan", "kupu-kupu", "élite"} {
+		if err := ValidateWord(word); err != nil {
+			t.Errorf("%s, unexpected error: %v", word, err)
+		}
+	}
+
+	for _, word := range []string{"Makan", "minum air", "makan2", "-nya", "kupu-"} {
+		if err := ValidateWord(word); err == nil {
+			t.Errorf("%s, expected error", word)
+		}
+	}
+}
+
+func TestDictionaryEncoding(t *testing.T) {
+	dict := NewDictionary("minum", "makan", "kupu-kupu")
+
+	buffer := bytes.Buffer{}
+	n, err := dict.Write(&buffer, ", ")
+	if expected := "kupu-kupu, makan, minum\n"; err != nil || buffer.String() != expected || n != int64(len(expected)) {
+		t.Errorf("expected: %q, result: %q (%d bytes, %v)", expected, buffer.String(), n, err)
+	}
+
+	text, _ := dict.MarshalText()
+	if expected := "kupu-kupu\nmakan\nminum\n"; string(text) != expected {
+		t.Errorf("expected text: %q, result: %q", expected, text)
+	}
+
+	data, _ := json.Marshal(dict)
+	if expected := `["kupu-kupu","makan","minum"]`; string(data) != expected {
+		t.Errorf("expected JSON: %s, result: %s", expected, data)
+	}
+
+	var fromText, fromJSON Dictionary
+	if err := fromText.UnmarshalText(text); err != nil || !reflect.DeepEqual(fromText, dict) {
+		t.Errorf("expected text round trip to return %v, result: %v (%v)", dict, fromText, err)
+	}
+
+	if err := json.Unmarshal(data, &fromJSON); err != nil || !reflect.DeepEqual(fromJSON, dict) {
+		t.Errorf("expected JSON round trip to return %v, result: %v (%v)", dict, fromJSON, err)
+	}
+
+	if err := json.Unmarshal([]byte(`["makan", "Minum"]`), &fromJSON); err == nil {
+		t.Errorf("expected error for uppercase word in JSON")
+	}
+}
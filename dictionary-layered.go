@@ -0,0 +1,57 @@
+package sastrawi
+
+// DictionaryLayer is a single layer in LayeredDictionary
+type DictionaryLayer struct {
+	// Name is used to identify the layer, e.g. "default", "medical" or "customer-x"
+	Name string
+
+	// Allow contains root words that added by this layer. It may be nil.
+	Allow RootLookup
+
+	// Deny contains root words from the lower layers that masked by this
+	// layer, so they are not found unless allowed again by the higher layers.
+	// It may be nil.
+	Deny RootLookup
+}
+
+// LayeredDictionary is RootLookup that stacks several layers of dictionary,
+// ordered from the lowest to the highest priority. For example, the default
+// dictionary can be used as the lowest layer, followed by domain specific
+// words, then by per customer exclusions:
+//
+//	dict := LayeredDictionary{
+//		{Name: "default", Allow: DefaultDictionary()},
+//		{Name: "medical", Allow: NewDictionary("diagnosis", "rawat")},
+//		{Name: "customer", Deny: NewDictionary("ligas", "petan")},
+//	}
+//
+// The layers are not copied, so modifying a layer's dictionary takes effect immediately.
+type LayeredDictionary []DictionaryLayer
+
+// Contains checks word starting from the highest layer. The word is found if it's
+// allowed by a layer before it's denied by any layer above it.
+func (layers LayeredDictionary) Contains(word string) bool {
+	for i := len(layers) - 1; i >= 0; i-- {
+		layer := layers[i]
+		if layer.Allow != nil && layer.Allow.Contains(word) {
+			return true
+		}
+
+		if layer.Deny != nil && layer.Deny.Contains(word) {
+			return false
+		}
+	}
+
+	return false
+}
+
+// Layer returns the layer with the specified name
+func (layers LayeredDictionary) Layer(name string) (DictionaryLayer, bool) {
+	for _, layer := range layers {
+		if layer.Name == name {
+			return layer, true
+		}
+	}
+
+	return DictionaryLayer{}, false
+}
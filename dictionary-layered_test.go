@@ -0,0 +1,48 @@
+package sastrawi
+
+import "testing"
+
+func TestLayeredDictionary(t *testing.T) {
+	base := NewDictionary("ligas", "petan", "tani", "kerja", "gugat")
+	dict := LayeredDictionary{
+		{Name: "default", Allow: base},
+		{Name: "legal", Allow: NewDictionary("dakwa")},
+		{Name: "customer", Deny: NewDictionary("ligas", "petan", "dakwa")},
+		{Name: "override", Allow: NewDictionary("dakwa")},
+	}
+
+	testItems := map[string]bool{
+		"tani":  true,
+		"gugat": true,
+		"ligas": false,
+		"petan": false,
+		"dakwa": true,
+		"makan": false,
+	}
+
+	for word, expected := range testItems {
+		if result := dict.Contains(word); result != expected {
+			t.Errorf("%s, expected: %v, result: %v", word, expected, result)
+		}
+	}
+
+	// Base dictionary must not be modified
+	if !base.Contains("ligas") {
+		t.Errorf("expected base dictionary to still contain ligas")
+	}
+
+	stemmer := NewStemmer(dict)
+	for _, item := range []testItem{
+		{value: "lemigas", expected: "lemigas"},
+		{value: "petani", expected: "tani"},
+		{value: "terdakwa", expected: "dakwa"},
+	} {
+		if result := stemmer.Stem(item.value); result != item.expected {
+			t.Errorf("%s, expected: %s, result: %s", item.value, item.expected, result)
+		}
+	}
+
+	if layer, ok := dict.Layer("legal"); !ok || !layer.Allow.Contains("dakwa") {
+		t.Errorf("expected to find legal layer")
+	}
+}
@@ -0,0 +1,35 @@
+package sastrawi
+
+// RootLookup is the database of root words that used by Stemmer. Dictionary is
+// the default implementation, but any other storage like trie, Bloom filter
+// fronted store or memory mapped file can be used as long as it implements
+// this interface. RootLookup must be safe to be used by multiple goroutines
+// as long as it's not modified.
+type RootLookup interface {
+	// Contains checks if word exists as root word
+	Contains(word string) bool
+}
+
+// LookupFunc is an adapter to allow the use of ordinary function as RootLookup
+type LookupFunc func(word string) bool
+
+// Contains returns fn(word)
+func (fn LookupFunc) Contains(word string) bool {
+	return fn(word)
+}
+
+// MultiLookup is RootLookup that combines several lookups. The lookups are
+// checked in order, so the one that most likely contains the word should be
+// put first.
+type MultiLookup []RootLookup
+
+// Contains checks if word exists in any of the lookups
+func (lookups MultiLookup) Contains(word string) bool {
+	for _, lookup := range lookups {
+		if lookup.Contains(word) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,73 @@
+package sastrawi
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// SyncDictionary is dictionary that safe to be read and modified by multiple
+// goroutines, e.g. to add root words while Stemmer is used by a running service.
+type SyncDictionary struct {
+	mutex   sync.RWMutex
+	words   Dictionary
+	version atomic.Uint64
+}
+
+// NewSyncDictionary creates new SyncDictionary that contains copy of dict
+func NewSyncDictionary(dict Dictionary) *SyncDictionary {
+	return &SyncDictionary{words: dict.clone()}
+}
+
+// Count returns the size of dictionary
+func (dictionary *SyncDictionary) Count() int {
+	dictionary.mutex.RLock()
+	defer dictionary.mutex.RUnlock()
+
+	return len(dictionary.words)
+}
+
+// Contains is used for to check if word exists within dictionary
+func (dictionary *SyncDictionary) Contains(word string) bool {
+	dictionary.mutex.RLock()
+	defer dictionary.mutex.RUnlock()
+
+	_, found := dictionary.words[word]
+	return found
+}
+
+// Add is used to append new words to dictionary
+func (dictionary *SyncDictionary) Add(words ...string) {
+	dictionary.mutex.Lock()
+	defer dictionary.mutex.Unlock()
+
+	dictionary.words.Add(words...)
+	dictionary.version.Add(1)
+}
+
+// Remove is used to remove some words from dictionary
+func (dictionary *SyncDictionary) Remove(words ...string) {
+	dictionary.mutex.Lock()
+	defer dictionary.mutex.Unlock()
+
+	dictionary.words.Remove(words...)
+	dictionary.version.Add(1)
+}
+
+// Replace replaces all words in dictionary with copy of dict at once,
+// so readers never see partially updated dictionary
+func (dictionary *SyncDictionary) Replace(dict Dictionary) {
+	words := dict.clone()
+	dictionary.mutex.Lock()
+	dictionary.words = words
+	dictionary.mutex.Unlock()
+
+	dictionary.version.Add(1)
+}
+
+// Snapshot returns copy of the current words in dictionary
+func (dictionary *SyncDictionary) Snapshot() Dictionary {
+	dictionary.mutex.RLock()
+	defer dictionary.mutex.RUnlock()
+
+	return dictionary.words.clone()
+}
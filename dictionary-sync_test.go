@@ -0,0 +1,52 @@
+package sastrawi
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestSyncDictionaryConcurrent(t *testing.T) {
+	dict := NewSyncDictionary(NewDictionary("makan", "minum"))
+	stemmer := NewStemmer(dict)
+	cache := NewCachedStemmer(stemmer, 16)
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 500; j++ {
+				stemmer.Stem("makanan")
+				cache.Stem("meminum")
+				dict.Contains("tidur")
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				dict.Add("tidur", "jalan")
+				dict.Remove("jalan")
+				if j%10 == 0 {
+					dict.Replace(NewDictionary("makan", "minum", "tidur"))
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if dict.Count() != 3 {
+		t.Errorf("expected 3 words, result: %v", dict.Snapshot().Words())
+	}
+
+	dict.Remove("tidur")
+	if result := cache.Stem("tertidur"); result != "tertidur" {
+		t.Errorf("tertidur after root removed, expected: tertidur, result: %s", result)
+	}
+
+	dict.Add("tidur")
+	if result := cache.Stem("tertidur"); result != "tidur" {
+		t.Errorf("tertidur after root added, expected: tidur, result: %s", result)
+	}
+}
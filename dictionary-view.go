@@ -0,0 +1,90 @@
+package sastrawi
+
+import "io"
+
+// DictionaryView is dictionary that shares its words with other views until it's
+// modified. The words are copied when Add or Remove called for the first time, so
+// modifying a view never affects the others. Like Dictionary, it's safe to be read
+// by multiple goroutines as long as it's not modified.
+//
+// Unlike Dictionary, its changes are counted, so CachedStemmer that uses it is
+// cleared when words added to or removed from it.
+type DictionaryView struct {
+	words   Dictionary
+	shared  bool
+	version uint64
+}
+
+// NewDictionaryView returns view that shares the words of dict. Since the words
+// are not copied, dict must not be modified as long as the view is used.
+func NewDictionaryView(dict Dictionary) *DictionaryView {
+	return &DictionaryView{words: dict, shared: true}
+}
+
+// Count returns the size of dictionary
+func (dictionary *DictionaryView) Count() int {
+	return len(dictionary.words)
+}
+
+// Contains is used for to check if word exists within dictionary
+func (dictionary *DictionaryView) Contains(word string) bool {
+	_, found := dictionary.words[word]
+	return found
+}
+
+// Add is used to append new words to dictionary
+func (dictionary *DictionaryView) Add(words ...string) {
+	dictionary.copyOnWrite()
+	dictionary.words.Add(words...)
+	dictionary.version++
+}
+
+// Remove is used to remove some words from dictionary
+func (dictionary *DictionaryView) Remove(words ...string) {
+	dictionary.copyOnWrite()
+	dictionary.words.Remove(words...)
+	dictionary.version++
+}
+
+// copyOnWrite copies the shared words before they're modified for the first time
+func (dictionary *DictionaryView) copyOnWrite() {
+	if dictionary.shared {
+		dictionary.words = dictionary.words.clone()
+		dictionary.shared = false
+	}
+}
+
+// Words returns all words in dictionary, sorted alphabetically
+func (dictionary *DictionaryView) Words() []string {
+	return dictionary.words.Words()
+}
+
+// Print is used for printing content of dictionary to stdout, where each word is separated by separator
+func (dictionary *DictionaryView) Print(separator string) {
+	dictionary.words.Print(separator)
+}
+
+// Write writes all words in dictionary to w in the same way as Dictionary.Write
+func (dictionary *DictionaryView) Write(w io.Writer, separator string) (int64, error) {
+	return dictionary.words.Write(w, separator)
+}
+
+// WriteTo writes all words in dictionary to w, one word per line and sorted alphabetically
+func (dictionary *DictionaryView) WriteTo(w io.Writer) (int64, error) {
+	return dictionary.words.WriteTo(w)
+}
+
+// MarshalText encodes dictionary as one word per line, sorted alphabetically
+func (dictionary *DictionaryView) MarshalText() ([]byte, error) {
+	return dictionary.words.MarshalText()
+}
+
+// MarshalJSON encodes dictionary as JSON array of words, sorted alphabetically
+func (dictionary *DictionaryView) MarshalJSON() ([]byte, error) {
+	return dictionary.words.MarshalJSON()
+}
+
+// Snapshot returns copy of the current words in dictionary
+func (dictionary *DictionaryView) Snapshot() Dictionary {
+	return dictionary.words.clone()
+}
@@ -0,0 +1,42 @@
+package sastrawi
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestDictionaryView(t *testing.T) {
+	dict := NewDictionary("makan", "minum")
+	first := NewDictionaryView(dict)
+	second := NewDictionaryView(dict)
+
+	first.Add("tidur")
+	first.Remove("makan")
+	if !reflect.DeepEqual(first.Words(), []string{"minum", "tidur"}) {
+		t.Errorf("expected [minum tidur], result: %v", first.Words())
+	}
+
+	// The other view and the shared dictionary are not modified
+	if !reflect.DeepEqual(second.Words(), []string{"makan", "minum"}) || dict.Count() != 2 {
+		t.Errorf("expected [makan minum], result: %v and %v", second.Words(), dict.Words())
+	}
+
+	snapshot := first.Snapshot()
+	snapshot.Add("jalan")
+	if first.Contains("jalan") || first.Count() != 2 {
+		t.Errorf("expected modifying snapshot doesn't affect the view")
+	}
+
+	if result, _ := json.Marshal(first); string(result) != `["minum","tidur"]` {
+		t.Errorf(`expected ["minum","tidur"], result: %s`, result)
+	}
+
+	// The changes are counted, so cached stemmer that uses the view is cleared
+	cache := NewCachedStemmer(NewStemmer(first), 10)
+	cache.Stem("meminum")
+	first.Remove("minum")
+	if result := cache.Stem("meminum"); result != "meminum" {
+		t.Errorf("meminum after root removed, expected: meminum, result: %s", result)
+	}
+}
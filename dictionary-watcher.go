@@ -0,0 +1,163 @@
+package sastrawi
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// DictionaryWatcher keeps SyncDictionary in sync with a dictionary file by polling
+// the file periodically. Since it's based on polling, it works on any file system
+// without relying on platform specific notification.
+//
+// The file is reloaded when its size or modification time changed. If the new
+// content can't be parsed, the last good version is kept. To avoid reading a file
+// that partially written, update the file by writing to a temporary file then
+// renaming it.
+type DictionaryWatcher struct {
+	path       string
+	dictionary *SyncDictionary
+	onError    func(error)
+
+	mutex    sync.Mutex
+	size     int64
+	modTime  time.Time
+	lastErr  error
+	reported string
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+// WatchDictionaryFile loads dictionary file in path, then checks it every interval and
+// reloads it when changed. The format of the file is the same as in LoadDictionary.
+// If onError is not nil, it's called when the file can't be loaded. The same error,
+// e.g. because the file is missing, is only reported once until the file loaded again.
+func WatchDictionaryFile(path string, interval time.Duration, onError func(error)) (*DictionaryWatcher, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("invalid watch interval %v", interval)
+	}
+
+	stat, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	dict, err := LoadDictionaryFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	watcher := &DictionaryWatcher{
+		path:       path,
+		dictionary: NewSyncDictionary(dict),
+		onError:    onError,
+		size:       stat.Size(),
+		modTime:    stat.ModTime(),
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+
+	go watcher.poll(interval)
+	return watcher, nil
+}
+
+// Dictionary returns the dictionary that kept in sync with the file. It can be used
+// as Stemmer's dictionary or as stop words list.
+func (watcher *DictionaryWatcher) Dictionary() *SyncDictionary {
+	return watcher.dictionary
+}
+
+// Reload checks the file immediately, and reloads it if it has changed
+func (watcher *DictionaryWatcher) Reload() error {
+	err := watcher.reload()
+	if watcher.isNewError(err) && watcher.onError != nil {
+		watcher.onError(err)
+	}
+
+	return err
+}
+
+// isNewError checks if err is different from the error that reported before, so the
+// same error isn't reported on every poll
+func (watcher *DictionaryWatcher) isNewError(err error) bool {
+	watcher.mutex.Lock()
+	defer watcher.mutex.Unlock()
+
+	if err == nil {
+		watcher.reported = ""
+		return false
+	}
+
+	if err.Error() == watcher.reported {
+		return false
+	}
+
+	watcher.reported = err.Error()
+	return true
+}
+
+func (watcher *DictionaryWatcher) reload() error {
+	watcher.mutex.Lock()
+	defer watcher.mutex.Unlock()
+
+	stat, err := os.Stat(watcher.path)
+	if err != nil {
+		watcher.lastErr = err
+		return err
+	}
+
+	if stat.Size() == watcher.size && stat.ModTime().Equal(watcher.modTime) {
+		return nil
+	}
+
+	// Save the file state first, so broken file only reported once
+	watcher.size = stat.Size()
+	watcher.modTime = stat.ModTime()
+
+	dict, err := LoadDictionaryFile(watcher.path)
+	if err != nil {
+		watcher.lastErr = err
+		return err
+	}
+
+	watcher.dictionary.Replace(dict)
+	watcher.lastErr = nil
+	return nil
+}
+
+// Err returns the error of the last reload, or nil if it succeed. Unlike Reload,
+// the error is kept until the file successfully reloaded.
+func (watcher *DictionaryWatcher) Err() error {
+	watcher.mutex.Lock()
+	defer watcher.mutex.Unlock()
+
+	return watcher.lastErr
+}
+
+// Close stops watching the file. The dictionary is still usable after that.
+func (watcher *DictionaryWatcher) Close() {
+	watcher.stopOnce.Do(func() {
+		close(watcher.stop)
+	})
+
+	<-watcher.done
+}
+
+func (watcher *DictionaryWatcher) poll(interval time.Duration) {
+	defer close(watcher.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-watcher.stop:
+			return
+		case <-ticker.C:
+			watcher.Reload()
+		}
+	}
+}
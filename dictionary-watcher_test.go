@@ -0,0 +1,102 @@
+package sastrawi
+
+import (
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDictionaryWatcher(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "roots.txt")
+	modTime := time.Now().Add(-time.Hour)
+	writeFile := func(content string) {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		// Make sure modification time changed, even in file system with low time resolution
+		modTime = modTime.Add(time.Second)
+		os.Chtimes(path, modTime, modTime)
+	}
+
+	writeFile("makan\n")
+
+	var nErrors int32
+	watcher, err := WatchDictionaryFile(path, time.Hour, func(error) {
+		atomic.AddInt32(&nErrors, 1)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer watcher.Close()
+
+	stemmer := NewStemmer(watcher.Dictionary())
+	if result := stemmer.Stem("minuman"); result != "minuman" {
+		t.Errorf("minuman before reload, expected: minuman, result: %s", result)
+	}
+
+	writeFile("makan\nminum\n")
+	if err := watcher.Reload(); err != nil {
+		t.Fatal(err)
+	}
+
+	if result := stemmer.Stem("minuman"); result != "minum" {
+		t.Errorf("minuman after reload, expected: minum, result: %s", result)
+	}
+
+	// Broken file must not replace the last good version
+	writeFile("makan\nMinum\n")
+	if err := watcher.Reload(); err == nil || watcher.Err() == nil {
+		t.Errorf("expected error when reloading broken file")
+	}
+
+	if result := stemmer.Stem("minuman"); result != "minum" {
+		t.Errorf("minuman after broken reload, expected: minum, result: %s", result)
+	}
+
+	// Unchanged broken file is only reported once
+	watcher.Reload()
+	if n := atomic.LoadInt32(&nErrors); n != 1 {
+		t.Errorf("expected 1 reported error, result: %d", n)
+	}
+
+	// Missing file is also only reported once, however many times it's checked
+	os.Remove(path)
+	for i := 0; i < 3; i++ {
+		if err := watcher.Reload(); err == nil {
+			t.Errorf("expected error when reloading missing file")
+		}
+	}
+
+	if n := atomic.LoadInt32(&nErrors); n != 2 {
+		t.Errorf("expected 2 reported errors, result: %d", n)
+	}
+
+	writeFile("makan\n")
+	if _, err := WatchDictionaryFile(path, 0, nil); err == nil {
+		t.Errorf("expected error for zero interval")
+	}
+}
+
+func TestDictionaryWatcherPolling(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stopwords.txt")
+	os.WriteFile(path, []byte("yang\n"), 0644)
+
+	watcher, err := WatchDictionaryFile(path, 10*time.Millisecond, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer watcher.Close()
+
+	os.WriteFile(path, []byte("yang\ndan\n"), 0644)
+	deadline := time.Now().Add(5 * time.Second)
+	for !watcher.Dictionary().Contains("dan") {
+		if time.Now().After(deadline) {
+			t.Fatal("expected file to be reloaded by polling")
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -1,7 +1,12 @@
 package sastrawi
 
 import (
-	"fmt"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"sort"
 )
 
 // Dictionary is map[string]struct{} that used as root words database
@@ -42,20 +47,115 @@ func (dictionary Dictionary) Remove(words ...string) {
 	}
 }
 
-// Print is used for printing content of dictionary, where each word is separated by separator
+// clone returns copy of dictionary, which is never nil
+func (dictionary Dictionary) clone() Dictionary {
+	dict := make(Dictionary, len(dictionary))
+	for word := range dictionary {
+		dict[word] = struct{}{}
+	}
+
+	return dict
+}
+
+// Words returns all words in dictionary, sorted alphabetically
+func (dictionary Dictionary) Words() []string {
+	words := make([]string, 0, len(dictionary))
+	for word := range dictionary {
+		words = append(words, word)
+	}
+
+	sort.Strings(words)
+	return words
+}
+
+// Print is used for printing content of dictionary to stdout, where each word is separated by separator
 func (dictionary Dictionary) Print(separator string) {
 	if separator == "" {
 		separator = ", "
 	}
 
-	index := 0
-	lenDictionary := len(dictionary)
-	for word := range dictionary {
-		index++
-		if index >= lenDictionary {
-			fmt.Println(word)
-		} else {
-			fmt.Print(word, separator)
+	dictionary.Write(os.Stdout, separator)
+}
+
+// Write writes all words in dictionary to w sorted alphabetically, where each word is
+// separated by separator. The output is ended by new line, unless dictionary is empty.
+func (dictionary Dictionary) Write(w io.Writer, separator string) (int64, error) {
+	if len(dictionary) == 0 {
+		return 0, nil
+	}
+
+	cw := &countingWriter{w: w}
+	bw := bufio.NewWriter(cw)
+	for i, word := range dictionary.Words() {
+		if i > 0 {
+			bw.WriteString(separator)
 		}
+
+		bw.WriteString(word)
 	}
+	bw.WriteString("\n")
+
+	err := bw.Flush()
+	return cw.n, err
+}
+
+// WriteTo writes all words in dictionary to w, one word per line and sorted alphabetically.
+// The output can be read back using LoadDictionary.
+func (dictionary Dictionary) WriteTo(w io.Writer) (int64, error) {
+	return dictionary.Write(w, "\n")
+}
+
+// MarshalText encodes dictionary as one word per line, sorted alphabetically
+func (dictionary Dictionary) MarshalText() ([]byte, error) {
+	buffer := bytes.Buffer{}
+	if _, err := dictionary.WriteTo(&buffer); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// UnmarshalText replaces content of dictionary with words in text, using the same format as LoadDictionary
+func (dictionary *Dictionary) UnmarshalText(text []byte) error {
+	dict, err := LoadDictionary(bytes.NewReader(text))
+	if err != nil {
+		return err
+	}
+
+	*dictionary = dict
+	return nil
+}
+
+// MarshalJSON encodes dictionary as JSON array of words, sorted alphabetically
+func (dictionary Dictionary) MarshalJSON() ([]byte, error) {
+	return json.Marshal(dictionary.Words())
+}
+
+// UnmarshalJSON replaces content of dictionary with words in JSON array
+func (dictionary *Dictionary) UnmarshalJSON(data []byte) error {
+	var words []string
+	if err := json.Unmarshal(data, &words); err != nil {
+		return err
+	}
+
+	for i, word := range words {
+		if reason := validateWord(word); reason != "" {
+			return &DictionaryError{Line: i + 1, Word: word, Reason: reason}
+		}
+	}
+
+	*dictionary = NewDictionary(words...)
+	return nil
+}
+
+// countingWriter is io.Writer that counts the bytes written to w
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
 }
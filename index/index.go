@@ -0,0 +1,216 @@
+// Package index is a lightweight in-memory inverted index for Indonesian text, for
+// applications that need full text search without external search engine. Documents
+// are analyzed using sastrawi.Analyzer, so searching "bangga" also finds documents
+// that contain "membanggakan" or "kebanggaan".
+//
+// The index can be searched using query with following syntax :
+//
+//	ekonomi rakyat          documents that contain both words
+//	ekonomi OR rakyat       documents that contain either word
+//	ekonomi -rakyat         documents that contain "ekonomi" but not "rakyat",
+//	ekonomi NOT rakyat      which can also be written using NOT
+//	"ekonomi rakyat"        documents that contain the phrase
+//	(ekonomi OR politik) AND rakyat
+//
+// The results are ranked using BM25.
+package index
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/RadhiFadlillah/go-sastrawi"
+)
+
+// Parameters of BM25 ranking
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// Index is in-memory inverted index. It's safe to be used by multiple goroutines.
+type Index struct {
+	mutex    sync.RWMutex
+	analyzer sastrawi.Analyzer
+
+	docs        map[int]*document
+	ids         map[string]int
+	postings    map[string]map[int][]int
+	nextNum     int
+	totalLength int
+}
+
+// document is the indexed document
+type document struct {
+	ID     string
+	Length int
+	Terms  []string
+}
+
+// Result is document that matched by search query
+type Result struct {
+	ID    string
+	Score float64
+}
+
+// New returns empty index that analyzes documents and queries using analyzer
+func New(analyzer sastrawi.Analyzer) *Index {
+	return &Index{
+		analyzer: analyzer,
+		docs:     map[int]*document{},
+		ids:      map[string]int{},
+		postings: map[string]map[int][]int{},
+	}
+}
+
+// NewDefault returns empty index that uses sastrawi.DefaultAnalyzer
+func NewDefault() *Index {
+	return New(sastrawi.DefaultAnalyzer())
+}
+
+// Count returns the number of documents in index
+func (index *Index) Count() int {
+	index.mutex.RLock()
+	defer index.mutex.RUnlock()
+
+	return len(index.docs)
+}
+
+// Contains checks if document with id exists in index
+func (index *Index) Contains(id string) bool {
+	index.mutex.RLock()
+	defer index.mutex.RUnlock()
+
+	_, exist := index.ids[id]
+	return exist
+}
+
+// Add analyzes text and adds it to index as document with id. If the document
+// already exists, it will be replaced.
+func (index *Index) Add(id, text string) {
+	tokens := index.analyzer.Analyze(text)
+
+	index.mutex.Lock()
+	defer index.mutex.Unlock()
+
+	index.remove(id)
+
+	num := index.nextNum
+	index.nextNum++
+
+	doc := &document{ID: id, Length: len(tokens)}
+	for _, token := range tokens {
+		positions := index.postings[token.Text]
+		if positions == nil {
+			positions = map[int][]int{}
+			index.postings[token.Text] = positions
+		}
+
+		if _, exist := positions[num]; !exist {
+			doc.Terms = append(doc.Terms, token.Text)
+		}
+
+		positions[num] = append(positions[num], token.Position)
+	}
+
+	index.docs[num] = doc
+	index.ids[id] = num
+	index.totalLength += doc.Length
+}
+
+// Remove removes document with id from index. It returns false if the document doesn't exist.
+func (index *Index) Remove(id string) bool {
+	index.mutex.Lock()
+	defer index.mutex.Unlock()
+
+	return index.remove(id)
+}
+
+func (index *Index) remove(id string) bool {
+	num, exist := index.ids[id]
+	if !exist {
+		return false
+	}
+
+	doc := index.docs[num]
+	for _, term := range doc.Terms {
+		delete(index.postings[term], num)
+		if len(index.postings[term]) == 0 {
+			delete(index.postings, term)
+		}
+	}
+
+	delete(index.docs, num)
+	delete(index.ids, id)
+	index.totalLength -= doc.Length
+
+	return true
+}
+
+// Search parses query then returns at most limit documents that matched by it, sorted
+// by their score. If limit is less than 1, all matched documents are returned.
+func (index *Index) Search(query string, limit int) ([]Result, error) {
+	q, err := index.ParseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return index.SearchQuery(q, limit), nil
+}
+
+// SearchQuery is like Search, but uses query that already parsed
+func (index *Index) SearchQuery(query Query, limit int) []Result {
+	index.mutex.RLock()
+	defer index.mutex.RUnlock()
+
+	if query == nil {
+		return []Result{}
+	}
+
+	matches := query.match(index)
+	terms := query.terms(nil)
+	results := make([]Result, 0, len(matches))
+	for num := range matches {
+		results = append(results, Result{
+			ID:    index.docs[num].ID,
+			Score: index.score(num, terms),
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+
+		return results[i].ID < results[j].ID
+	})
+
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+
+	return results
+}
+
+// score calculates BM25 score of document num for terms
+func (index *Index) score(num int, terms []string) float64 {
+	nDocs := float64(len(index.docs))
+	avgLength := float64(index.totalLength) / nDocs
+	docLength := float64(index.docs[num].Length)
+
+	score := 0.0
+	for _, term := range terms {
+		postings := index.postings[term]
+		tf := float64(len(postings[num]))
+		if tf == 0 {
+			continue
+		}
+
+		df := float64(len(postings))
+		idf := math.Log(1 + (nDocs-df+0.5)/(df+0.5))
+		score += idf * tf * (bm25K1 + 1) / (tf + bm25K1*(1-bm25B+bm25B*docLength/avgLength))
+	}
+
+	return score
+}
@@ -0,0 +1,234 @@
+package index
+
+import (
+	"bytes"
+	"encoding/gob"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/RadhiFadlillah/go-sastrawi"
+)
+
+var testDocs = map[string]string{
+	"1": "Pertumbuhan ekonomi rakyat yang membanggakan",
+	"2": "Ekonomi dan politik Indonesia",
+	"3": "Politik rakyat kecil",
+	"4": "Rakyat bangga dengan kebanggaan ekonomi rakyat",
+	"5": "Buku-buku pelajaran untuk anak-anak",
+}
+
+func newTestIndex() *Index {
+	index := NewDefault()
+	for id, text := range testDocs {
+		index.Add(id, text)
+	}
+
+	return index
+}
+
+func resultIDs(results []Result) []string {
+	ids := []string{}
+	for _, result := range results {
+		ids = append(ids, result.ID)
+	}
+
+	return ids
+}
+
+func TestSearch(t *testing.T) {
+	type testItem struct {
+		value    string
+		expected []string
+	}
+
+	index := newTestIndex()
+	testData := []testItem{
+		{"ekonomi", []string{"2", "1", "4"}},
+		{"bangga", []string{"4", "1"}},
+		{"ekonomi rakyat", []string{"4", "1"}},
+		{"ekonomi AND rakyat", []string{"4", "1"}},
+		{"ekonomi OR politik", []string{"2", "3", "1", "4"}},
+		{"rakyat -ekonomi", []string{"3"}},
+		{"rakyat NOT ekonomi", []string{"3"}},
+		{"NOT rakyat", []string{"2", "5"}},
+		{"(ekonomi OR politik) AND rakyat", []string{"3", "4", "1"}},
+		{"politik OR ekonomi rakyat", []string{"3", "2", "4", "1"}},
+		{`"ekonomi rakyat"`, []string{"4", "1"}},
+		{`"rakyat ekonomi"`, []string{}},
+		{`"tumbuh ekonomi"`, []string{"1"}},
+		{`"rakyat yang bangga"`, []string{"1"}},
+		{`"rakyat bangga"`, []string{"4"}},
+		{`rakyat -"ekonomi rakyat"`, []string{"3"}},
+		{"buku anak", []string{"5"}},
+		{"yang", []string{}},
+		{"yang dan", []string{}},
+		{"yang politik", []string{"3", "2"}},
+		{"sejarah", []string{}},
+		{"", []string{}},
+	}
+
+	for _, data := range testData {
+		results, err := index.Search(data.value, 0)
+		if err != nil {
+			t.Errorf("%s, unexpected error: %v", data.value, err)
+			continue
+		}
+
+		if result := resultIDs(results); !reflect.DeepEqual(result, data.expected) {
+			t.Errorf("%s, expected: %v, result: %v", data.value, data.expected, result)
+		}
+	}
+
+	results, _ := index.Search("ekonomi OR politik", 2)
+	if result := resultIDs(results); !reflect.DeepEqual(result, []string{"2", "3"}) {
+		t.Errorf("limit 2, expected: [2 3], result: %v", result)
+	}
+
+	for _, query := range []string{`"ekonomi rakyat`, "(ekonomi", "ekonomi)", "ekonomi NOT"} {
+		if _, err := index.Search(query, 0); err == nil {
+			t.Errorf("%s, expected error", query)
+		}
+	}
+}
+
+func TestSearchQuery(t *testing.T) {
+	index := newTestIndex()
+	query := AndQuery{
+		OrQuery{TermQuery{Term: "ekonomi"}, TermQuery{Term: "politik"}},
+		NotQuery{Query: PhraseQuery{Terms: []string{"ekonomi", "rakyat"}}},
+	}
+
+	expected := []string{"2", "3"}
+	if result := resultIDs(index.SearchQuery(query, 0)); !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected: %v, result: %v", expected, result)
+	}
+}
+
+func TestIndexUpdate(t *testing.T) {
+	index := newTestIndex()
+	if !index.Remove("2") || index.Remove("2") {
+		t.Errorf("document should only be removed once")
+	}
+
+	if index.Count() != 4 || index.Contains("2") {
+		t.Errorf("expected 4 documents without 2, result: %d", index.Count())
+	}
+
+	index.Add("3", "Sejarah ekonomi")
+	expected := []string{"3", "1", "4"}
+	if results, _ := index.Search("ekonomi", 0); !reflect.DeepEqual(resultIDs(results), expected) {
+		t.Errorf("expected: %v, result: %v", expected, resultIDs(results))
+	}
+
+	if results, _ := index.Search("politik", 0); len(results) != 0 {
+		t.Errorf("replaced document should not be found, result: %v", resultIDs(results))
+	}
+}
+
+func TestIndexPersistence(t *testing.T) {
+	index := newTestIndex()
+	index.Remove("5")
+
+	buffer := bytes.Buffer{}
+	if err := index.Save(&buffer); err != nil {
+		t.Fatalf("failed to save index: %v", err)
+	}
+
+	loaded, err := Load(&buffer, sastrawi.DefaultAnalyzer())
+	if err != nil {
+		t.Fatalf("failed to load index: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "index.gob")
+	if err := loaded.SaveFile(path); err != nil {
+		t.Fatalf("failed to save index file: %v", err)
+	}
+
+	loaded, err = LoadFile(path, sastrawi.DefaultAnalyzer())
+	if err != nil {
+		t.Fatalf("failed to load index file: %v", err)
+	}
+
+	for _, query := range []string{"ekonomi", `"rakyat yang bangga"`, "NOT rakyat", "anak"} {
+		expected, _ := index.Search(query, 0)
+		result, _ := loaded.Search(query, 0)
+		if !reflect.DeepEqual(result, expected) {
+			t.Errorf("%s, expected: %v, result: %v", query, expected, result)
+		}
+	}
+
+	// The numbers of new documents continue after the loaded ones
+	loaded.Add("6", "Ekonomi kreatif")
+	if results, _ := loaded.Search("kreatif", 0); !reflect.DeepEqual(resultIDs(results), []string{"6"}) {
+		t.Errorf("expected: [6], result: %v", resultIDs(results))
+	}
+
+	if _, err := Load(bytes.NewBufferString("bukan index"), sastrawi.DefaultAnalyzer()); err == nil {
+		t.Errorf("expected error for invalid index")
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	docs := func() map[int]*document {
+		return map[int]*document{0: {ID: "1", Length: 2, Terms: []string{"ekonomi", "rakyat"}}}
+	}
+
+	testItems := map[string]snapshot{
+		"missing document": {Docs: docs(), NextNum: 1, Postings: map[string]map[int][]int{
+			"ekonomi": {0: {0}}, "rakyat": {0: {1}, 1: {0}},
+		}},
+		"wrong length": {Docs: docs(), NextNum: 1, Postings: map[string]map[int][]int{
+			"ekonomi": {0: {0}}, "rakyat": {0: {1, 2}},
+		}},
+		"missing term": {Docs: docs(), NextNum: 1, Postings: map[string]map[int][]int{
+			"ekonomi": {0: {0, 1}},
+		}},
+		"unsorted positions": {Docs: docs(), NextNum: 1, Postings: map[string]map[int][]int{
+			"ekonomi": {0: {2, 0}},
+		}},
+		"invalid number": {Docs: docs(), NextNum: 0, Postings: map[string]map[int][]int{
+			"ekonomi": {0: {0}}, "rakyat": {0: {1}},
+		}},
+	}
+
+	for name, content := range testItems {
+		content.Version = formatVersion
+		buffer := bytes.Buffer{}
+		if err := gob.NewEncoder(&buffer).Encode(content); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := Load(&buffer, sastrawi.DefaultAnalyzer()); err == nil {
+			t.Errorf("%s, expected error", name)
+		}
+	}
+}
+
+func TestIndexConcurrency(t *testing.T) {
+	index := newTestIndex()
+	wg := sync.WaitGroup{}
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				index.Add("new", "Ekonomi rakyat kecil")
+				index.Remove("new")
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				index.Search(`ekonomi OR "rakyat kecil"`, 3)
+			}
+		}()
+	}
+
+	wg.Wait()
+	if index.Count() != len(testDocs) {
+		t.Errorf("expected %d documents, result: %d", len(testDocs), index.Count())
+	}
+}
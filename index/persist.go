@@ -0,0 +1,173 @@
+package index
+
+import (
+	"bufio"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/RadhiFadlillah/go-sastrawi"
+)
+
+// formatVersion is the version of saved index format
+const formatVersion = 1
+
+// snapshot is the content of index that saved into file
+type snapshot struct {
+	Version  int
+	Docs     map[int]*document
+	Postings map[string]map[int][]int
+	NextNum  int
+}
+
+// Save writes the content of index into w. The analyzer is not saved, so the index
+// must be loaded using the same analyzer that used to create it.
+func (index *Index) Save(w io.Writer) error {
+	index.mutex.RLock()
+	defer index.mutex.RUnlock()
+
+	return gob.NewEncoder(w).Encode(snapshot{
+		Version:  formatVersion,
+		Docs:     index.docs,
+		Postings: index.postings,
+		NextNum:  index.nextNum,
+	})
+}
+
+// SaveFile saves index into file in path. The file is written into temporary file
+// first, so the old file is kept intact when saving failed.
+func (index *Index) SaveFile(path string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	buffer := bufio.NewWriter(tmp)
+	err = index.Save(buffer)
+	if err == nil {
+		err = buffer.Flush()
+	}
+
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// Load reads index that saved by Save from r. The documents and queries will be
+// analyzed using analyzer.
+func Load(r io.Reader, analyzer sastrawi.Analyzer) (*Index, error) {
+	var content snapshot
+	if err := gob.NewDecoder(r).Decode(&content); err != nil {
+		return nil, err
+	}
+
+	if content.Version != formatVersion {
+		return nil, fmt.Errorf("unsupported index version %d", content.Version)
+	}
+
+	if err := content.validate(); err != nil {
+		return nil, fmt.Errorf("invalid index: %w", err)
+	}
+
+	index := New(analyzer)
+	index.nextNum = content.NextNum
+	if content.Postings != nil {
+		index.postings = content.Postings
+	}
+
+	for num, doc := range content.Docs {
+		index.docs[num] = doc
+		index.ids[doc.ID] = num
+		index.totalLength += doc.Length
+	}
+
+	return index, nil
+}
+
+// LoadFile loads index from file in path. See Load for details.
+func LoadFile(path string, analyzer sastrawi.Analyzer) (*Index, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	index, err := Load(bufio.NewReader(f), analyzer)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return index, nil
+}
+
+// validate checks that documents and postings in snapshot are consistent with each other,
+// so damaged or crafted file can't make the index panic when it's searched or modified
+func (content snapshot) validate() error {
+	ids := map[string]struct{}{}
+	for num, doc := range content.Docs {
+		if doc == nil {
+			return fmt.Errorf("document %d is empty", num)
+		}
+
+		if num < 0 || num >= content.NextNum {
+			return fmt.Errorf("document %q has invalid number %d", doc.ID, num)
+		}
+
+		if _, exist := ids[doc.ID]; exist {
+			return fmt.Errorf("document %q is duplicated", doc.ID)
+		}
+
+		ids[doc.ID] = struct{}{}
+	}
+
+	lengths := map[int]int{}
+	nTerms := map[int]int{}
+	for term, postings := range content.Postings {
+		for num, positions := range postings {
+			doc, exist := content.Docs[num]
+			if !exist {
+				return fmt.Errorf("term %q refers to missing document %d", term, num)
+			}
+
+			if len(positions) == 0 || positions[0] < 0 || !sort.IntsAreSorted(positions) {
+				return fmt.Errorf("term %q has invalid positions in document %q", term, doc.ID)
+			}
+
+			lengths[num] += len(positions)
+			nTerms[num]++
+		}
+	}
+
+	for num, doc := range content.Docs {
+		if doc.Length != lengths[num] {
+			return fmt.Errorf("document %q has length %d, but %d terms indexed", doc.ID, doc.Length, lengths[num])
+		}
+
+		// Terms are used to remove the document from postings, so they must be
+		// exactly the terms that have postings for the document
+		terms := map[string]struct{}{}
+		for _, term := range doc.Terms {
+			if _, exist := content.Postings[term][num]; !exist {
+				return fmt.Errorf("term %q of document %q is not indexed", term, doc.ID)
+			}
+
+			terms[term] = struct{}{}
+		}
+
+		if len(terms) != len(doc.Terms) || len(terms) != nTerms[num] {
+			return fmt.Errorf("document %q has inconsistent terms", doc.ID)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,424 @@
+package index
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Query is parsed search query. The terms in query must be already analyzed, i.e. they
+// are the root words as stored in index. Use Index.ParseQuery to create query from text.
+type Query interface {
+	// match returns the documents that matched by query
+	match(index *Index) docSet
+
+	// terms appends the terms used for ranking into dst
+	terms(dst []string) []string
+}
+
+// docSet is set of document numbers
+type docSet map[int]struct{}
+
+// TermQuery matches documents that contain the term
+type TermQuery struct {
+	Term string
+}
+
+// PhraseQuery matches documents that contain the terms in order. Positions are the
+// position of each term relative to each other, so the gaps left by removed stop words
+// can be matched as well. If Positions is nil, the terms must be adjacent.
+type PhraseQuery struct {
+	Terms     []string
+	Positions []int
+}
+
+// AndQuery matches documents that matched by all of its queries
+type AndQuery []Query
+
+// OrQuery matches documents that matched by any of its queries
+type OrQuery []Query
+
+// NotQuery matches documents that not matched by its query
+type NotQuery struct {
+	Query Query
+}
+
+func (query TermQuery) match(index *Index) docSet {
+	result := docSet{}
+	for num := range index.postings[query.Term] {
+		result[num] = struct{}{}
+	}
+
+	return result
+}
+
+func (query TermQuery) terms(dst []string) []string {
+	return append(dst, query.Term)
+}
+
+func (query PhraseQuery) match(index *Index) docSet {
+	result := docSet{}
+	if len(query.Terms) == 0 {
+		return result
+	}
+
+	for num, starts := range index.postings[query.Terms[0]] {
+		for _, start := range starts {
+			if query.matchAt(index, num, start) {
+				result[num] = struct{}{}
+				break
+			}
+		}
+	}
+
+	return result
+}
+
+// matchAt checks if the phrase exists in document num, started from position start
+func (query PhraseQuery) matchAt(index *Index, num int, start int) bool {
+	for i := 1; i < len(query.Terms); i++ {
+		offset := i
+		if query.Positions != nil {
+			offset = query.Positions[i] - query.Positions[0]
+		}
+
+		positions := index.postings[query.Terms[i]][num]
+		idx := sort.SearchInts(positions, start+offset)
+		if idx == len(positions) || positions[idx] != start+offset {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (query PhraseQuery) terms(dst []string) []string {
+	return append(dst, query.Terms...)
+}
+
+func (query AndQuery) match(index *Index) docSet {
+	var result docSet
+	excluded := []docSet{}
+	for _, subQuery := range query {
+		if not, isNot := subQuery.(NotQuery); isNot {
+			excluded = append(excluded, not.Query.match(index))
+			continue
+		}
+
+		matches := subQuery.match(index)
+		if result == nil {
+			result = matches
+			continue
+		}
+
+		for num := range result {
+			if _, exist := matches[num]; !exist {
+				delete(result, num)
+			}
+		}
+	}
+
+	// If all queries are negation, the documents are excluded from the whole index
+	if result == nil {
+		result = allDocs(index)
+	}
+
+	for _, matches := range excluded {
+		for num := range matches {
+			delete(result, num)
+		}
+	}
+
+	return result
+}
+
+func (query AndQuery) terms(dst []string) []string {
+	for _, subQuery := range query {
+		dst = subQuery.terms(dst)
+	}
+
+	return dst
+}
+
+func (query OrQuery) match(index *Index) docSet {
+	result := docSet{}
+	for _, subQuery := range query {
+		for num := range subQuery.match(index) {
+			result[num] = struct{}{}
+		}
+	}
+
+	return result
+}
+
+func (query OrQuery) terms(dst []string) []string {
+	for _, subQuery := range query {
+		dst = subQuery.terms(dst)
+	}
+
+	return dst
+}
+
+func (query NotQuery) match(index *Index) docSet {
+	result := allDocs(index)
+	for num := range query.Query.match(index) {
+		delete(result, num)
+	}
+
+	return result
+}
+
+func (query NotQuery) terms(dst []string) []string {
+	return dst
+}
+
+func allDocs(index *Index) docSet {
+	result := make(docSet, len(index.docs))
+	for num := range index.docs {
+		result[num] = struct{}{}
+	}
+
+	return result
+}
+
+// ParseQuery parses text into Query. The words in text are analyzed using the analyzer
+// of index, so stop words are ignored and the other words are stemmed. Words that
+// separated by space must all exist in the document, unless they are separated by OR.
+// Word that prefixed by - or NOT must not exist in the document. Words in double quotes
+// are searched as phrase, and parentheses can be used to group the queries. It returns
+// nil query if text has no searchable words.
+func (index *Index) ParseQuery(text string) (Query, error) {
+	lexemes, err := lexQuery(text)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := queryParser{index: index, lexemes: lexemes}
+	query, err := parser.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if parser.pos < len(parser.lexemes) {
+		return nil, fmt.Errorf("unexpected %q in query", parser.lexemes[parser.pos].text)
+	}
+
+	return query, nil
+}
+
+// queryLexeme is the smallest part of query, i.e. word, phrase, operator or parenthesis
+type queryLexeme struct {
+	text    string
+	phrase  bool
+	negated bool
+}
+
+// lexQuery splits text into lexemes
+func lexQuery(text string) ([]queryLexeme, error) {
+	lexemes := []queryLexeme{}
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		switch r := runes[i]; {
+		case unicode.IsSpace(r):
+			i++
+
+		case r == '(' || r == ')':
+			lexemes = append(lexemes, queryLexeme{text: string(r)})
+			i++
+
+		case r == '"' || r == '-' && i+1 < len(runes) && runes[i+1] == '"':
+			negated := r == '-'
+			if negated {
+				i++
+			}
+
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+
+			if end == len(runes) {
+				return nil, fmt.Errorf("unterminated phrase in query")
+			}
+
+			lexemes = append(lexemes, queryLexeme{text: string(runes[i+1 : end]), phrase: true, negated: negated})
+			i = end + 1
+
+		default:
+			end := i + 1
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && !strings.ContainsRune(`()"`, runes[end]) {
+				end++
+			}
+
+			word := string(runes[i:end])
+			negated := len(word) > 1 && word[0] == '-'
+			if negated {
+				word = word[1:]
+			}
+
+			lexemes = append(lexemes, queryLexeme{text: word, negated: negated})
+			i = end
+		}
+	}
+
+	return lexemes, nil
+}
+
+// queryParser parses lexemes with following grammar, where NOT binds tighter than AND,
+// and AND binds tighter than OR :
+//
+//	or      = and { "OR" and }
+//	and     = unary { [ "AND" ] unary }
+//	unary   = "NOT" unary | primary
+//	primary = "(" or ")" | phrase | word
+type queryParser struct {
+	index   *Index
+	lexemes []queryLexeme
+	pos     int
+}
+
+// peek returns the text of current lexeme if it's an operator or parenthesis
+func (parser *queryParser) peek() string {
+	if parser.pos >= len(parser.lexemes) {
+		return ""
+	}
+
+	lexeme := parser.lexemes[parser.pos]
+	if lexeme.phrase || lexeme.negated {
+		return ""
+	}
+
+	switch lexeme.text {
+	case "OR", "AND", "NOT", "(", ")":
+		return lexeme.text
+	}
+
+	return ""
+}
+
+func (parser *queryParser) parseOr() (Query, error) {
+	queries := OrQuery{}
+	for {
+		query, err := parser.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		if query != nil {
+			queries = append(queries, query)
+		}
+
+		if parser.peek() != "OR" {
+			break
+		}
+
+		parser.pos++
+	}
+
+	switch len(queries) {
+	case 0:
+		return nil, nil
+	case 1:
+		return queries[0], nil
+	}
+
+	return queries, nil
+}
+
+func (parser *queryParser) parseAnd() (Query, error) {
+	queries := AndQuery{}
+	for parser.pos < len(parser.lexemes) {
+		operator := parser.peek()
+		if operator == "OR" || operator == ")" {
+			break
+		}
+
+		if operator == "AND" {
+			parser.pos++
+			continue
+		}
+
+		query, err := parser.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		if query != nil {
+			queries = append(queries, query)
+		}
+	}
+
+	switch len(queries) {
+	case 0:
+		return nil, nil
+	case 1:
+		return queries[0], nil
+	}
+
+	return queries, nil
+}
+
+func (parser *queryParser) parseUnary() (Query, error) {
+	if parser.peek() != "NOT" {
+		return parser.parsePrimary()
+	}
+
+	parser.pos++
+	if parser.pos >= len(parser.lexemes) {
+		return nil, fmt.Errorf("NOT without operand in query")
+	}
+
+	query, err := parser.parseUnary()
+	if query == nil || err != nil {
+		return nil, err
+	}
+
+	return NotQuery{Query: query}, nil
+}
+
+func (parser *queryParser) parsePrimary() (Query, error) {
+	if parser.peek() == "(" {
+		parser.pos++
+		query, err := parser.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if parser.peek() != ")" {
+			return nil, fmt.Errorf("unclosed parenthesis in query")
+		}
+
+		parser.pos++
+		return query, nil
+	}
+
+	lexeme := parser.lexemes[parser.pos]
+	parser.pos++
+
+	query := parser.analyze(lexeme.text)
+	if query != nil && lexeme.negated {
+		return NotQuery{Query: query}, nil
+	}
+
+	return query, nil
+}
+
+// analyze converts text into TermQuery, or PhraseQuery if it contains several terms
+func (parser *queryParser) analyze(text string) Query {
+	tokens := parser.index.analyzer.Analyze(text)
+	switch len(tokens) {
+	case 0:
+		return nil
+	case 1:
+		return TermQuery{Term: tokens[0].Text}
+	}
+
+	phrase := PhraseQuery{}
+	for _, token := range tokens {
+		phrase.Terms = append(phrase.Terms, token.Text)
+		phrase.Positions = append(phrase.Positions, token.Position)
+	}
+
+	return phrase
+}
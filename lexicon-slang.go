@@ -0,0 +1,36 @@
+package sastrawi
+
+// DefaultSlangLexicon is default database of informal and slang words in Indonesian
+// language, e.g. abbreviations that commonly used in social media, along with their
+// standard form
+func DefaultSlangLexicon() Lexicon {
+	return NewLexicon(
+		"aja", "saja", "ajah", "saja", "ak", "aku", "akoh", "aku", "aq", "aku", "ama", "sama",
+		"ampe", "sampai", "ato", "atau", "atw", "atau", "bgt", "banget", "bngt", "banget",
+		"bkn", "bukan", "blm", "belum", "blom", "belum", "bnr", "benar", "bener", "benar", "bs", "bisa",
+		"bsa", "bisa", "bgmn", "bagaimana", "bt", "bosan", "cm", "cuma", "cmn", "cuma", "cuman", "cuma",
+		"cpt", "cepat", "cepet", "cepat", "cewe", "perempuan", "cewek", "perempuan", "cowo", "laki-laki", "cowok", "laki-laki", "dah", "sudah",
+		"dgn", "dengan", "dg", "dengan", "dlm", "dalam", "dl", "dulu", "dlu", "dulu", "doang", "saja",
+		"dpt", "dapat", "dri", "dari", "emg", "memang", "emang", "memang", "enggak", "tidak",
+		"engga", "tidak", "ga", "tidak", "gak", "tidak", "gk", "tidak", "gaada", "tidak ada",
+		"gamau", "tidak mau", "gatau", "tidak tahu", "gbs", "tidak bisa", "gimana", "bagaimana",
+		"gmn", "bagaimana", "gitu", "begitu", "gt", "begitu", "gmana", "bagaimana",
+		"gpp", "tidak apa-apa", "gapapa", "tidak apa-apa", "gw", "aku", "gua", "aku", "gue", "aku", "hrs", "harus",
+		"hr", "hari", "jd", "jadi", "jdi", "jadi", "jg", "juga", "jgn", "jangan", "jgk", "juga",
+		"jln", "jalan", "kalo", "kalau", "klo", "kalau", "kl", "kalau", "kk", "kakak", "kak", "kakak",
+		"kmrn", "kemarin", "kmren", "kemarin", "kmu", "kamu", "km", "kamu", "knp", "kenapa",
+		"kpn", "kapan", "krn", "karena", "krna", "karena", "karna", "karena", "kyk", "seperti",
+		"lg", "lagi", "lgi", "lagi", "liat", "lihat", "lo", "kamu", "loe", "kamu", "lw", "kamu", "lu", "kamu",
+		"mw", "mau", "mo", "mau", "maap", "maaf", "mksd", "maksud", "mksh", "terima kasih", "makasih", "terima kasih", "msh", "masih",
+		"masi", "masih", "mslh", "masalah", "nggak", "tidak", "ngga", "tidak", "ngak", "tidak",
+		"nyokap", "ibu", "bokap", "ayah", "org", "orang", "pd", "pada", "pengen", "ingin", "pengin", "ingin",
+		"pgn", "ingin", "pake", "pakai", "pk", "pakai", "plis", "tolong", "pls", "tolong",
+		"sbg", "sebagai", "sblm", "sebelum", "sdh", "sudah", "sdg", "sedang", "sj", "saja", "sm", "sama",
+		"smpe", "sampai", "smpai", "sampai", "spt", "seperti", "skrg", "sekarang",
+		"skg", "sekarang", "skrng", "sekarang", "sy", "saya", "tau", "tahu", "tdk", "tidak",
+		"tp", "tapi", "tpi", "tapi", "trs", "terus", "trus", "terus", "ttg", "tentang",
+		"tq", "terima kasih", "trims", "terima kasih", "makasi", "terima kasih", "udh", "sudah",
+		"udah", "sudah", "uda", "sudah", "utk", "untuk", "untk", "untuk", "wkt", "waktu", "yg", "yang",
+		"yng", "yang", "y", "ya", "aj", "saja",
+	)
+}
@@ -0,0 +1,124 @@
+package sastrawi
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Lexicon is object for mapping informal words into their standard form, e.g. "yg" into
+// "yang". The standard form might consist of several words, e.g. "gpp" into "tidak apa-apa".
+type Lexicon map[string]string
+
+// NewLexicon creates new Lexicon from pairs of word and its standard form. It panics
+// if the number of arguments is odd, since the last word has no standard form.
+func NewLexicon(pairs ...string) Lexicon {
+	if len(pairs)%2 != 0 {
+		panic("sastrawi: NewLexicon needs pairs of word and standard form")
+	}
+
+	lexicon := Lexicon{}
+	for i := 0; i+1 < len(pairs); i += 2 {
+		lexicon[pairs[i]] = pairs[i+1]
+	}
+
+	return lexicon
+}
+
+// Count returns the number of words in lexicon
+func (lexicon Lexicon) Count() int {
+	return len(lexicon)
+}
+
+// Lookup returns the standard form of word
+func (lexicon Lexicon) Lookup(word string) (string, bool) {
+	standard, exist := lexicon[word]
+	return standard, exist
+}
+
+// Add adds word and its standard form to lexicon, replacing the old one if exists
+func (lexicon Lexicon) Add(word, standard string) {
+	word = strings.TrimSpace(word)
+	standard = strings.TrimSpace(standard)
+	if word != "" && standard != "" {
+		lexicon[word] = standard
+	}
+}
+
+// Remove removes words from lexicon
+func (lexicon Lexicon) Remove(words ...string) {
+	for _, word := range words {
+		delete(lexicon, strings.TrimSpace(word))
+	}
+}
+
+// Words returns all words in lexicon, sorted alphabetically
+func (lexicon Lexicon) Words() []string {
+	words := make([]string, 0, len(lexicon))
+	for word := range lexicon {
+		words = append(words, word)
+	}
+
+	sort.Strings(words)
+	return words
+}
+
+// LoadLexicon creates new Lexicon from r, which contains one word per line followed by
+// its standard form, separated by whitespace, e.g. "yg yang" or "gpp tidak apa-apa".
+// Blank lines and text after # are ignored.
+func LoadLexicon(r io.Reader) (Lexicon, error) {
+	lexicon := Lexicon{}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if line == 1 {
+			text = strings.TrimPrefix(text, "\ufeff")
+		}
+
+		if idx := strings.IndexByte(text, '#'); idx >= 0 {
+			text = text[:idx]
+		}
+
+		fields := strings.Fields(text)
+		if len(fields) == 0 {
+			continue
+		}
+
+		word := fields[0]
+		if len(fields) == 1 {
+			return nil, &DictionaryError{Line: line, Word: word, Reason: "doesn't have standard form"}
+		}
+
+		if strings.IndexFunc(word, unicode.IsUpper) >= 0 {
+			return nil, &DictionaryError{Line: line, Word: word, Reason: "contains uppercase letter"}
+		}
+
+		lexicon[word] = strings.Join(fields[1:], " ")
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return lexicon, nil
+}
+
+// LoadLexiconFile creates new Lexicon from file in path. See LoadLexicon for the file format.
+func LoadLexiconFile(path string) (Lexicon, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	lexicon, err := LoadLexicon(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return lexicon, nil
+}
@@ -0,0 +1,56 @@
+package sastrawi
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadLexicon(t *testing.T) {
+	text := "\ufeff# slang words\nyg yang\n\ngpp   tidak apa-apa # multiple words\r\nbgt\tbanget\n"
+	lexicon, err := LoadLexicon(strings.NewReader(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := NewLexicon("yg", "yang", "gpp", "tidak apa-apa", "bgt", "banget")
+	if !reflect.DeepEqual(lexicon, expected) {
+		t.Errorf("expected: %v, result: %v", expected, lexicon)
+	}
+
+	lexicon.Add("tq", "terima kasih")
+	lexicon.Remove("yg")
+	if words := lexicon.Words(); !reflect.DeepEqual(words, []string{"bgt", "gpp", "tq"}) {
+		t.Errorf("expected: %q, result: %q", []string{"bgt", "gpp", "tq"}, words)
+	}
+
+	var dictErr *DictionaryError
+	for _, text := range []string{"yg yang\nbgt\n", "yg yang\nBgt banget\n"} {
+		_, err := LoadLexicon(strings.NewReader(text))
+		if !errors.As(err, &dictErr) || dictErr.Line != 2 {
+			t.Errorf("%q, expected error in line 2, result: %v", text, err)
+		}
+	}
+}
+
+func TestDefaultSlangLexicon(t *testing.T) {
+	// Every word must be mapped into its standard form, not into another slang word
+	lexicon := DefaultSlangLexicon()
+	for _, word := range lexicon.Words() {
+		standard, _ := lexicon.Lookup(word)
+		for _, part := range strings.Fields(standard) {
+			if _, exist := lexicon.Lookup(part); exist {
+				t.Errorf("%s, standard form %q is also informal word", word, standard)
+			}
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected panic for odd number of arguments")
+		}
+	}()
+
+	NewLexicon("yg", "yang", "bgt")
+}
@@ -0,0 +1,144 @@
+package sastrawi
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Normalizer is object for converting informal words, which commonly used in social
+// media, into their standard form before stemming and stop words filtering.
+type Normalizer struct {
+	// Lexicon maps informal words into their standard form
+	Lexicon Lexicon
+
+	// Dictionary is used to decide how repeated letters are squeezed, e.g. "maaaaf"
+	// into "maaf" instead of "maf". If nil, repeated letters are squeezed into one.
+	Dictionary RootLookup
+}
+
+// NewNormalizer returns Normalizer that uses the default slang lexicon and dictionary
+func NewNormalizer() Normalizer {
+	return Normalizer{
+		Lexicon:    DefaultSlangLexicon(),
+		Dictionary: DefaultDictionary(),
+	}
+}
+
+// leetLetters maps digits that used in place of letters, e.g. "s4y4" for "saya"
+var leetLetters = map[rune]rune{
+	'0': 'o', '1': 'i', '3': 'e', '4': 'a', '5': 's', '6': 'g', '7': 't', '9': 'g',
+}
+
+// Normalize returns the standard form of word, which should be in lower case. The
+// standard form might consist of several words, e.g. "gpp" into "tidak apa-apa".
+// Word is normalized in following order :
+//  1. Look up word in lexicon, e.g. "yg" into "yang".
+//  2. Replace digits that used as letters, e.g. "s4y4" into "saya", and "2" that
+//     used as reduplication mark, e.g. "anak2" into "anak-anak".
+//  3. Squeeze letters that repeated three times or more, e.g. "bangeeet" into "banget".
+//  4. Look up the result in lexicon again, e.g. "bgttt" into "bgt" then "banget".
+func (normalizer Normalizer) Normalize(word string) string {
+	if standard, exist := normalizer.Lexicon.Lookup(word); exist {
+		return standard
+	}
+
+	result := replaceLeet(word)
+	result = normalizer.squeeze(result)
+	if standard, exist := normalizer.Lexicon.Lookup(result); exist {
+		return standard
+	}
+
+	return result
+}
+
+// replaceLeet replaces digits that used as letters in word. To avoid mangling
+// words like "covid19", "b4" and numbers, the digits are only replaced when they're
+// not consecutive, and the word has at least two letters and no more digits than
+// letters. "2" is only treated as reduplication mark after at least three letters,
+// so short words like "ke2" are kept.
+func replaceLeet(word string) string {
+	nLetters, nDigits := 0, 0
+	prevDigit := false
+	for _, r := range word {
+		digit := isDigit(r)
+		switch {
+		case digit && prevDigit:
+			return word
+		case digit:
+			nDigits++
+		case unicode.IsLetter(r):
+			nLetters++
+		}
+
+		prevDigit = digit
+	}
+
+	if nDigits == 0 || nLetters < 2 || nLetters < nDigits {
+		return word
+	}
+
+	// Handle reduplication mark, e.g. "anak2" and "anak2nya"
+	if idx := strings.IndexByte(word, '2'); idx >= 3 {
+		base := word[:idx]
+		if i := strings.LastIndexFunc(base, func(r rune) bool { return !unicode.IsLetter(r) }); i >= 0 {
+			base = base[i+1:]
+		}
+
+		if len(base) >= 3 && strings.IndexFunc(base, unicode.IsDigit) < 0 {
+			word = word[:idx] + "-" + base + word[idx+1:]
+		}
+	}
+
+	leet := strings.Map(func(r rune) rune {
+		if letter, exist := leetLetters[r]; exist {
+			return letter
+		}
+
+		return r
+	}, word)
+
+	// Keep the word if some digits can't be replaced
+	if strings.IndexFunc(leet, unicode.IsDigit) >= 0 {
+		return word
+	}
+
+	return leet
+}
+
+// squeeze reduces letters that repeated three times or more in word
+func (normalizer Normalizer) squeeze(word string) string {
+	runes := []rune(word)
+	single := make([]rune, 0, len(runes))
+	double := make([]rune, 0, len(runes))
+	squeezed := false
+
+	for i := 0; i < len(runes); {
+		j := i + 1
+		for j < len(runes) && runes[j] == runes[i] {
+			j++
+		}
+
+		switch {
+		case j-i >= 3 && unicode.IsLetter(runes[i]):
+			squeezed = true
+			single = append(single, runes[i])
+			double = append(double, runes[i], runes[i])
+		default:
+			single = append(single, runes[i:j]...)
+			double = append(double, runes[i:j]...)
+		}
+
+		i = j
+	}
+
+	if !squeezed {
+		return word
+	}
+
+	if normalizer.Dictionary != nil && !normalizer.Dictionary.Contains(string(single)) &&
+		normalizer.Dictionary.Contains(string(double)) {
+		return string(double)
+	}
+
+	return string(single)
+}
@@ -0,0 +1,64 @@
+package sastrawi
+
+import "testing"
+
+func TestNormalizer(t *testing.T) {
+	normalizer := NewNormalizer()
+	testItems := []testItem{
+		{"yg", "yang"},
+		{"gak", "tidak"},
+		{"nggak", "tidak"},
+		{"bgt", "banget"},
+		{"udh", "sudah"},
+		{"tdk", "tidak"},
+		{"aja", "saja"},
+		{"dgn", "dengan"},
+		{"gpp", "tidak apa-apa"},
+		{"bangeeet", "banget"},
+		{"bgttt", "banget"},
+		{"maaaaf", "maaf"},
+		{"kerennnn", "keren"},
+		{"s4y4", "saya"},
+		{"s3m4ng4t", "semangat"},
+		{"anak2", "anak-anak"},
+		{"anak2nya", "anak-anaknya"},
+		{"gw", "aku"},
+		{"lo", "kamu"},
+		{"kyk", "seperti"},
+		{"pengen", "ingin"},
+		{"cewe", "perempuan"},
+		{"mksh", "terima kasih"},
+		{"makasih", "terima kasih"},
+		{"mau", "mau"},
+		{"sma", "sma"},
+		{"ke2", "ke2"},
+		{"b4", "b4"},
+		{"covid19", "covid19"},
+		{"2020", "2020"},
+		{"buku", "buku"},
+	}
+
+	for _, item := range testItems {
+		if result := normalizer.Normalize(item.value); result != item.expected {
+			t.Errorf("%s, expected: %s, result: %s", item.value, item.expected, result)
+		}
+	}
+
+	// Normalized words can be stemmed and filtered
+	stemmer := NewStemmer(DefaultDictionary())
+	stopwords := DefaultStopword()
+	if result := stemmer.Stem(normalizer.Normalize("m4k4nan")); result != "makan" {
+		t.Errorf("%s, expected: %s, result: %s", "m4k4nan", "makan", result)
+	}
+
+	for _, word := range []string{"yg", "udh", "tdk", "dgn"} {
+		if normalized := normalizer.Normalize(word); !stopwords.Contains(normalized) {
+			t.Errorf("%s is normalized into %s which is not a stop word", word, normalized)
+		}
+	}
+
+	// Without dictionary, repeated letters are squeezed into one
+	if result := (Normalizer{}).Normalize("maaaaf"); result != "maf" {
+		t.Errorf("%s, expected: %s, result: %s", "maaaaf", "maf", result)
+	}
+}
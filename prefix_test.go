@@ -0,0 +1,450 @@
+package sastrawi
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// The regular expressions below are the original definition of prefix and infix rules.
+// They are kept as reference for the hand written matchers in stemmer.go.
+var (
+	rxPrefixMe1  = regexp.MustCompile(`^me([lrwy][aiueo].*)$`) // me{l|r|w|y}V => me-{l|r|w|y}V
+	rxPrefixMe2  = regexp.MustCompile(`^mem([bfv].*)$`)        // mem{b|f|v} => mem-{b|f|v}
+	rxPrefixMe3  = regexp.MustCompile(`^mem(pe.*)$`)           // mempe => mem-pe
+	rxPrefixMe4  = regexp.MustCompile(`^mem(r?[aiueo].*)$`)    // mem{rV|V} => mem-{rV|V} OR me-p{rV|V}
+	rxPrefixMe5  = regexp.MustCompile(`^men([cdjstz].*)$`)     // men{c|d|j|s|t|z} => men-{c|d|j|s|t|z}
+	rxPrefixMe6  = regexp.MustCompile(`^men([aiueo].*)$`)      // menV => me-nV OR me-tV
+	rxPrefixMe7  = regexp.MustCompile(`^meng([ghqk].*)$`)      // meng{g|h|q|k} => meng-{g|h|q|k}
+	rxPrefixMe8  = regexp.MustCompile(`^meng(([aiueo])(.*))$`) // mengV => meng-V OR meng-kV OR me-ngV OR mengV- where V = 'e'
+	rxPrefixMe9  = regexp.MustCompile(`^meny(([aiueo])(.*))$`) // menyV => meny-sV OR me-nyV to stem menyala
+	rxPrefixMe10 = regexp.MustCompile(`^mem(p[^e].*)$`)        // mempV => mem-pA where A != 'e'
+
+	rxPrefixPe1  = regexp.MustCompile(`^pe([wy][aiueo].*)$`)              // pe{w|y}V => pe-{w|y}V
+	rxPrefixPe2  = regexp.MustCompile(`^per([aiueo].*)$`)                 // perV => per-V OR pe-rV
+	rxPrefixPe3  = regexp.MustCompile(`^per([^aiueor][a-z][^e].*)$`)      // perCAP => per-CAP where C != 'r' and P != 'er'
+	rxPrefixPe4  = regexp.MustCompile(`^per([^aiueor][a-z]er[aiueo].*)$`) // perCAerV => per-CAerV where C != 'r'
+	rxPrefixPe5  = regexp.MustCompile(`^pem([bfv].*)$`)                   // pem{b|f|v} => pem-{b|f|v}
+	rxPrefixPe6  = regexp.MustCompile(`^pem(r?[aiueo].*)$`)               // pem{rV|V} => pe-m{rV|V} OR pe-p{rV|V}
+	rxPrefixPe7  = regexp.MustCompile(`^pen([cdjstz].*)$`)                // pen{c|d|j|s|t|z} => pen-{c|d|j|s|t|z}
+	rxPrefixPe8  = regexp.MustCompile(`^pen([aiueo].*)$`)                 // penV => pe-nV OR pe-tV
+	rxPrefixPe9  = regexp.MustCompile(`^peng([^aiueo].*)$`)               // pengC => peng-C
+	rxPrefixPe10 = regexp.MustCompile(`^peng(([aiueo])(.*))$`)            // pengV => peng-V OR peng-kV OR pengV- where V = 'e'
+	rxPrefixPe11 = regexp.MustCompile(`^peny([aiueo].*)$`)                // penyV => peny-sV OR pe-nyV
+	rxPrefixPe12 = regexp.MustCompile(`^pe(l[aiueo].*)$`)                 // pelV => pe-lV OR pel-V for pelajar
+	rxPrefixPe13 = regexp.MustCompile(`^pe[^aiueorwylmn](er[aiueo].*)$`)  // peCerV => per-erV where C != {r|w|y|l|m|n}
+	rxPrefixPe14 = regexp.MustCompile(`^pe([^aiueorwylmn][^e].*)$`)       // peCP => pe-CP where C != {r|w|y|l|m|n} and P != 'er'
+	rxPrefixPe15 = regexp.MustCompile(`^pe([^aiueorwylmn]er[^aiueo].*)$`) // peC1erC2 => pe-C1erC2 where C1 != {r|w|y|l|m|n}
+
+	rxPrefixBe1 = regexp.MustCompile(`^ber([aiueo].*)$`)                 // berV => ber-V || be-rV
+	rxPrefixBe2 = regexp.MustCompile(`^ber([^aiueor][a-z][^e].*)$`)      // berCAP => ber-CAP where C != 'r' and P != 'er'
+	rxPrefixBe3 = regexp.MustCompile(`^ber([^aiueor][a-z]er[aiueo].*)$`) // berCAerV => ber-CAerV where C != 'r'
+	rxPrefixBe4 = regexp.MustCompile(`^bel(ajar)$`)                      // belajar => bel-ajar
+	rxPrefixBe5 = regexp.MustCompile(`^be([^aiueorl]er[^aiueo].*)$`)     // beC1erC2 => be-C1erC2 where C1 != {'r'|'l'}
+
+	rxPrefixTe1 = regexp.MustCompile(`^ter([aiueo].*)$`)             // terV => ter-V OR te-rV
+	rxPrefixTe2 = regexp.MustCompile(`^ter([^aiueor]er[aiueo].*)$`)  // terCerV => ter-CerV where C != 'r'
+	rxPrefixTe3 = regexp.MustCompile(`^ter([^aiueor][^e].*)$`)       // terCP => ter-CP where C != 'r' and P != 'er'
+	rxPrefixTe4 = regexp.MustCompile(`^te([^aiueor]er[^aiueo].*)$`)  // teC1erC2 => te-C1erC2 where C1 != 'r'
+	rxPrefixTe5 = regexp.MustCompile(`^ter([^aiueor]er[^aiueo].*)$`) // terC1erC2 => ter-C1erC2 where C1 != 'r'
+
+	rxInfix1 = regexp.MustCompile(`^(([^aiueo])e[rlm])([aiueo].*)$`) // Ce{r|l|m}V => Ce{r|l|m}V OR CV
+	rxInfix2 = regexp.MustCompile(`^(([^aiueo])in)([aiueo].*)$`)     // CinV => CinV OR CV
+)
+
+func removePrefixMeRegex(word string) (string, []string, int) {
+	// Pattern 1
+	// me{l|r|w|y}V => me-{l|r|w|y}V
+	matches := rxPrefixMe1.FindStringSubmatch(word)
+	if len(matches) != 0 {
+		return matches[1], nil, 1
+	}
+
+	// Pattern 2
+	// mem{b|f|v} => mem-{b|f|v}
+	matches = rxPrefixMe2.FindStringSubmatch(word)
+	if len(matches) != 0 {
+		return matches[1], nil, 2
+	}
+
+	// Pattern 3
+	// mempe => mem-pe
+	matches = rxPrefixMe3.FindStringSubmatch(word)
+	if len(matches) != 0 {
+		return matches[1], nil, 3
+	}
+
+	// Pattern 4
+	// mem{rV|V} => mem-{rV|V} OR me-p{rV|V}
+	matches = rxPrefixMe4.FindStringSubmatch(word)
+	if len(matches) != 0 {
+		return matches[1], []string{"m", "p"}, 4
+	}
+
+	// Pattern 5
+	// men{c|d|j|s|t|z} => men-{c|d|j|s|t|z}
+	matches = rxPrefixMe5.FindStringSubmatch(word)
+	if len(matches) != 0 {
+		return matches[1], nil, 5
+	}
+
+	// Pattern 6
+	// menV => nV OR tV
+	matches = rxPrefixMe6.FindStringSubmatch(word)
+	if len(matches) != 0 {
+		return matches[1], []string{"n", "t"}, 6
+	}
+
+	// Pattern 7
+	// meng{g|h|q|k} => meng-{g|h|q|k}
+	matches = rxPrefixMe7.FindStringSubmatch(word)
+	if len(matches) != 0 {
+		return matches[1], nil, 7
+	}
+
+	// Pattern 8
+	// mengV => meng-V OR meng-kV OR me-ngV OR mengV- where V = 'e'
+	matches = rxPrefixMe8.FindStringSubmatch(word)
+	if len(matches) != 0 {
+		if matches[2] == "e" {
+			return matches[3], nil, 8
+		}
+
+		return matches[1], []string{"ng", "k"}, 8
+	}
+
+	// Pattern 9
+	// menyV => meny-sV OR me-nyV to stem menyala
+	matches = rxPrefixMe9.FindStringSubmatch(word)
+	if len(matches) != 0 {
+		if matches[2] == "a" {
+			return "ny" + matches[1], nil, 9
+		}
+
+		return "s" + matches[1], nil, 9
+	}
+
+	// Pattern 10
+	// mempV => mem-pA where A != 'e'
+	matches = rxPrefixMe10.FindStringSubmatch(word)
+	if len(matches) != 0 {
+		return matches[1], nil, 10
+	}
+
+	return word, nil, 0
+}
+
+func removePrefixPeRegex(word string) (string, []string, int) {
+	// Pattern 1
+	// pe{w|y}V => pe-{w|y}V
+	matches := rxPrefixPe1.FindStringSubmatch(word)
+	if len(matches) != 0 {
+		return matches[1], nil, 1
+	}
+
+	// Pattern 2
+	// perV => per-V OR pe-rV
+	matches = rxPrefixPe2.FindStringSubmatch(word)
+	if len(matches) != 0 {
+		return matches[1], []string{"r"}, 2
+	}
+
+	// Pattern 3
+	// perCAP => per-CAP where C != 'r' and P != 'er'
+	matches = rxPrefixPe3.FindStringSubmatch(word)
+	if len(matches) != 0 {
+		return matches[1], nil, 3
+	}
+
+	// Pattern 4
+	// perCAerV => per-CAerV where C != 'r'
+	matches = rxPrefixPe4.FindStringSubmatch(word)
+	if len(matches) != 0 {
+		return matches[1], nil, 4
+	}
+
+	// Pattern 5
+	// pem{b|f|v} => pem-{b|f|v}
+	matches = rxPrefixPe5.FindStringSubmatch(word)
+	if len(matches) != 0 {
+		return matches[1], nil, 5
+	}
+
+	// Pattern 6
+	// pem{rV|V} => pe-m{rV|V} OR pe-p{rV|V}
+	matches = rxPrefixPe6.FindStringSubmatch(word)
+	if len(matches) != 0 {
+		return matches[1], []string{"m", "p"}, 6
+	}
+
+	// Pattern 7
+	// pen{c|d|j|s|t|z} => pen-{c|d|j|s|t|z}
+	matches = rxPrefixPe7.FindStringSubmatch(word)
+	if len(matches) != 0 {
+		return matches[1], nil, 7
+	}
+
+	// Pattern 8
+	// penV => pe-nV OR pe-tV
+	matches = rxPrefixPe8.FindStringSubmatch(word)
+	if len(matches) != 0 {
+		return matches[1], []string{"n", "t"}, 8
+	}
+
+	// Pattern 9
+	// pengC => peng-C
+	matches = rxPrefixPe9.FindStringSubmatch(word)
+	if len(matches) != 0 {
+		return matches[1], nil, 9
+	}
+
+	// Pattern 10
+	// pengV => peng-V OR peng-kV OR pengV- where V = 'e'
+	matches = rxPrefixPe10.FindStringSubmatch(word)
+	if len(matches) != 0 {
+		if matches[2] == "e" {
+			return matches[3], nil, 10
+		}
+
+		return matches[1], []string{"k"}, 10
+	}
+
+	// Pattern 11
+	// penyV => peny-sV OR pe-nyV
+	matches = rxPrefixPe11.FindStringSubmatch(word)
+	if len(matches) != 0 {
+		return matches[1], []string{"s", "ny"}, 11
+	}
+
+	// Pattern 12
+	// pelV => pe-lV OR pel-V for pelajar
+	matches = rxPrefixPe12.FindStringSubmatch(word)
+	if len(matches) != 0 {
+		if word == "pelajar" {
+			return "ajar", nil, 12
+		}
+
+		return matches[1], nil, 12
+	}
+
+	// Pattern 13
+	// peCerV => peC-erV where C != {r|w|y|l|m|n}
+	matches = rxPrefixPe13.FindStringSubmatch(word)
+	if len(matches) != 0 {
+		return matches[1], nil, 13
+	}
+
+	// Pattern 14
+	// peCP => pe-CP where C != {r|w|y|l|m|n} and P != 'er'
+	matches = rxPrefixPe14.FindStringSubmatch(word)
+	if len(matches) != 0 {
+		return matches[1], nil, 14
+	}
+
+	// Pattern 15
+	// peC1erC2 => pe-C1erC2 where C1 != {r|w|y|l|m|n}
+	matches = rxPrefixPe15.FindStringSubmatch(word)
+	if len(matches) != 0 {
+		return matches[1], nil, 15
+	}
+
+	return word, nil, 0
+}
+
+func removePrefixBeRegex(word string) (string, []string, int) {
+	// Pattern 1
+	// berV => ber-V OR be-rV
+	matches := rxPrefixBe1.FindStringSubmatch(word)
+	if len(matches) != 0 {
+		return matches[1], []string{"r"}, 1
+	}
+
+	// Pattern 2
+	// berCAP => ber-CAP where C != 'r' and P != 'er'
+	matches = rxPrefixBe2.FindStringSubmatch(word)
+	if len(matches) != 0 {
+		return matches[1], nil, 2
+	}
+
+	// Pattern 3
+	// berCAerV => ber-CAerV where C != 'r'
+	matches = rxPrefixBe3.FindStringSubmatch(word)
+	if len(matches) != 0 {
+		return matches[1], nil, 3
+	}
+
+	// Pattern 4
+	// belajar => bel-ajar
+	matches = rxPrefixBe4.FindStringSubmatch(word)
+	if len(matches) != 0 {
+		return matches[1], nil, 4
+	}
+
+	// Pattern 5
+	// beC1erC2 => be-C1erC2 where C1 != {'r'|'l'}
+	matches = rxPrefixBe5.FindStringSubmatch(word)
+	if len(matches) != 0 {
+		return matches[1], nil, 5
+	}
+
+	return word, nil, 0
+}
+
+func removePrefixTeRegex(word string) (string, []string, int) {
+	// Pattern 1
+	// terV => ter-V OR te-rV
+	matches := rxPrefixTe1.FindStringSubmatch(word)
+	if len(matches) != 0 {
+		return matches[1], []string{"r"}, 1
+	}
+
+	// Pattern 2
+	// terCerV => ter-CerV where C != 'r'
+	matches = rxPrefixTe2.FindStringSubmatch(word)
+	if len(matches) != 0 {
+		return matches[1], nil, 2
+	}
+
+	// Pattern 3
+	// terCP => ter-CP where C != 'r' and P != 'er'
+	matches = rxPrefixTe3.FindStringSubmatch(word)
+	if len(matches) != 0 {
+		return matches[1], nil, 3
+	}
+
+	// Pattern 4
+	// teC1erC2 => te-C1erC2 where C1 != 'r'
+	matches = rxPrefixTe4.FindStringSubmatch(word)
+	if len(matches) != 0 {
+		return matches[1], nil, 4
+	}
+
+	// Pattern 5
+	// terC1erC2 => ter-C1erC2 where C1 != 'r'
+	matches = rxPrefixTe5.FindStringSubmatch(word)
+	if len(matches) != 0 {
+		return matches[1], nil, 5
+	}
+
+	return word, nil, 0
+}
+
+func removeInfixRegex(word string) (string, []string, int) {
+	// Pattern 1
+	// CerV => CerV OR CV
+	matches := rxInfix1.FindStringSubmatch(word)
+	if len(matches) != 0 {
+		return matches[3], []string{matches[1], matches[2]}, 1
+	}
+
+	// Pattern 2
+	// CinV => CinV OR CV
+	matches = rxInfix2.FindStringSubmatch(word)
+	if len(matches) != 0 {
+		return matches[3], []string{matches[1], matches[2]}, 2
+	}
+
+	return word, nil, 0
+}
+
+type prefixRemover func(string) (string, []string, int)
+
+func TestPrefixRemoverDifferential(t *testing.T) {
+	stemmer := Stemmer{}
+	removers := []struct {
+		name  string
+		fast  prefixRemover
+		regex prefixRemover
+	}{
+		{name: "me", fast: stemmer.removePrefixMe, regex: removePrefixMeRegex},
+		{name: "pe", fast: stemmer.removePrefixPe, regex: removePrefixPeRegex},
+		{name: "be", fast: stemmer.removePrefixBe, regex: removePrefixBeRegex},
+		{name: "te", fast: stemmer.removePrefixTe, regex: removePrefixTeRegex},
+		{name: "infix", fast: stemmer.removeInfix, regex: removeInfixRegex},
+	}
+
+	// Every prefix that has its own rule, followed by every combination of
+	// three characters that distinguished by the rules, plus some characters
+	// that regex handles specially
+	prefixes := []string{"", "m", "me", "mem", "memp", "mempe", "men", "meng", "meny",
+		"p", "pe", "per", "pem", "pen", "peng", "peny", "pel",
+		"b", "be", "ber", "bel", "belajar", "t", "te", "ter", "l", "k", "ki", "kin"}
+	chars := []string{"a", "e", "i", "r", "l", "m", "n", "w", "y", "b", "p", "c", "g", "k", "s", "z", "x",
+		"-", "\n", "é", "\xff"}
+
+	words := []string{"pelajar", "belajar", "lemigas", "kinerja"}
+	for _, prefix := range prefixes {
+		words = append(words, prefix)
+		for _, c1 := range chars {
+			words = append(words, prefix+c1)
+			for _, c2 := range chars {
+				words = append(words, prefix+c1+c2)
+				for _, c3 := range chars {
+					words = append(words, prefix+c1+c2+c3, prefix+c1+c2+c3+"an")
+				}
+			}
+		}
+	}
+
+	for _, remover := range removers {
+		for _, word := range words {
+			fastResult, fastRecoding, fastPattern := remover.fast(word)
+			regexResult, regexRecoding, regexPattern := remover.regex(word)
+			if fastResult != regexResult || fastPattern != regexPattern ||
+				strings.Join(fastRecoding, ",") != strings.Join(regexRecoding, ",") {
+				t.Errorf("%s %q, expected: %q %q %d, result: %q %q %d", remover.name, word,
+					regexResult, regexRecoding, regexPattern,
+					fastResult, fastRecoding, fastPattern)
+			}
+		}
+	}
+}
+
+func BenchmarkRemovePrefix(b *testing.B) {
+	words := []string{"menyuarakan", "mempengaruhi", "pembangunan", "perekonomian",
+		"bertebaran", "terpercaya", "pekerja", "lemigas", "kinerja", "pelajar"}
+	stemmer := Stemmer{}
+
+	b.Run("handwritten", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, word := range words {
+				stemmer.removePrefix(word)
+			}
+		}
+	})
+
+	b.Run("regex", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, word := range words {
+				switch word[:2] {
+				case "me":
+					removePrefixMeRegex(word)
+				case "pe":
+					removePrefixPeRegex(word)
+				case "be":
+					removePrefixBeRegex(word)
+				case "te":
+					removePrefixTeRegex(word)
+				default:
+					removeInfixRegex(word)
+				}
+			}
+		}
+	})
+}
+
+func BenchmarkStem(b *testing.B) {
+	words := []string{"menyuarakan", "mempengaruhi", "pembangunan", "perekonomian",
+		"bertebaran", "terpercaya", "pekerja", "lemigas", "kinerja", "keberuntunganmu"}
+	stemmer := NewStemmer(DefaultDictionary())
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, word := range words {
+			stemmer.Stem(word)
+		}
+	}
+}
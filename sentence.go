@@ -0,0 +1,189 @@
+package sastrawi
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Sentence is a sentence that found in text, along with its position in the text
+type Sentence struct {
+	// Text is the sentence without the surrounding whitespaces
+	Text string
+
+	// Start and End are the byte offsets of Text in the original text
+	Start int
+	End   int
+
+	// StartRune and EndRune are the character offsets of Text in the original text
+	StartRune int
+	EndRune   int
+}
+
+// SentenceSplitter splits text into sentences
+type SentenceSplitter struct {
+	// Abbreviations is list of words that not ended the sentence when followed by
+	// period, e.g. "dr" and "dll". The words are in lower case and without period.
+	Abbreviations RootLookup
+}
+
+// SplitSentences splits text into sentences using the default abbreviations
+func SplitSentences(text string) []Sentence {
+	return SentenceSplitter{Abbreviations: defaultAbbreviations}.Split(text)
+}
+
+var defaultAbbreviations = DefaultAbbreviation()
+
+// Split splits text into sentences. Sentence is ended by '.', '!', '?' or '…', including
+// the closing quotes and brackets after it, when it's followed by whitespace then upper
+// case letter, digit, opening quote or the end of text. Period after abbreviation, initial
+// and inside number, e.g. "dr.", "S.H." and "1.500,50", doesn't end sentence. Blank line
+// always ends sentence.
+func (splitter SentenceSplitter) Split(text string) []Sentence {
+	sentences := []Sentence{}
+	start := 0
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		switch {
+		case isSentenceTerminator(r):
+			end := skipRunes(text, i, func(r rune) bool {
+				return isSentenceTerminator(r) || isClosingQuote(r)
+			})
+
+			if splitter.isSentenceEnd(text, i, end) {
+				sentences = appendSentence(sentences, text, start, end)
+				start = end
+			}
+
+			i = end
+
+		case r == '\n':
+			end := skipRunes(text, i+size, func(r rune) bool {
+				return r != '\n' && unicode.IsSpace(r)
+			})
+
+			if end < len(text) && text[end] == '\n' {
+				sentences = appendSentence(sentences, text, start, i)
+				start = end
+			}
+
+			i = end
+
+		default:
+			i += size
+		}
+	}
+
+	sentences = appendSentence(sentences, text, start, len(text))
+	setSentenceRuneOffsets(text, sentences)
+
+	return sentences
+}
+
+// isSentenceEnd checks if terminators in text[start:end] ends the sentence
+func (splitter SentenceSplitter) isSentenceEnd(text string, start, end int) bool {
+	// Check what comes after the terminators
+	if end < len(text) {
+		r, _ := utf8.DecodeRuneInString(text[end:])
+		if !unicode.IsSpace(r) {
+			return false
+		}
+
+		next := skipRunes(text, end, unicode.IsSpace)
+		if next < len(text) {
+			r, _ = utf8.DecodeRuneInString(text[next:])
+			if !unicode.IsUpper(r) && !unicode.IsDigit(r) && !isOpeningQuote(r) {
+				return false
+			}
+		}
+	}
+
+	// Single period might be part of abbreviation or initial
+	if text[start] != '.' || end-start > 1 && isSentenceTerminator(rune(text[start+1])) {
+		return true
+	}
+
+	wordStart := start
+	for wordStart > 0 {
+		r, size := utf8.DecodeLastRuneInString(text[:wordStart])
+		if !unicode.IsLetter(r) {
+			break
+		}
+
+		wordStart -= size
+	}
+
+	word := text[wordStart:start]
+	if utf8.RuneCountInString(word) == 1 {
+		return false
+	}
+
+	return word == "" || splitter.Abbreviations == nil ||
+		!splitter.Abbreviations.Contains(strings.ToLower(word))
+}
+
+// appendSentence appends text[start:end] to sentences if it's not empty
+func appendSentence(sentences []Sentence, text string, start, end int) []Sentence {
+	for start < end {
+		r, size := utf8.DecodeRuneInString(text[start:])
+		if !unicode.IsSpace(r) {
+			break
+		}
+
+		start += size
+	}
+
+	for end > start {
+		r, size := utf8.DecodeLastRuneInString(text[:end])
+		if !unicode.IsSpace(r) {
+			break
+		}
+
+		end -= size
+	}
+
+	if start == end {
+		return sentences
+	}
+
+	return append(sentences, Sentence{Text: text[start:end], Start: start, End: end})
+}
+
+// setSentenceRuneOffsets fills the character offsets of sentences
+func setSentenceRuneOffsets(text string, sentences []Sentence) {
+	offset, runeOffset := 0, 0
+	for i := range sentences {
+		runeOffset += utf8.RuneCountInString(text[offset:sentences[i].Start])
+		sentences[i].StartRune = runeOffset
+
+		runeOffset += utf8.RuneCountInString(sentences[i].Text)
+		sentences[i].EndRune = runeOffset
+		offset = sentences[i].End
+	}
+}
+
+// skipRunes returns position of the first rune after text[i] that doesn't match fn
+func skipRunes(text string, i int, fn func(rune) bool) int {
+	for i < len(text) {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		if !fn(r) {
+			break
+		}
+
+		i += size
+	}
+
+	return i
+}
+
+func isSentenceTerminator(r rune) bool {
+	return r == '.' || r == '!' || r == '?' || r == '…'
+}
+
+func isOpeningQuote(r rune) bool {
+	return r == '"' || r == '\'' || r == '“' || r == '‘' || r == '(' || r == '['
+}
+
+func isClosingQuote(r rune) bool {
+	return r == '"' || r == '\'' || r == '”' || r == '’' || r == ')' || r == ']'
+}
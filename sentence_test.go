@@ -0,0 +1,61 @@
+package sastrawi
+
+import (
+	"reflect"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSplitSentences(t *testing.T) {
+	text := "Sdr. Budi, S.H. tinggal di Jl. Merdeka no. 5, Bandung. Ia membayar Rp. 1.500,50 untuk buku, " +
+		"pena, dll. kemarin. Apa kabar?! \"Baik,\" katanya.\n\nJudul tanpa titik\n  \n" +
+		"Dr. Ani berkata: “Saya setuju.” Rapat selesai pukul 10.30. 2020 adalah tahun yang berat… " +
+		"Kunjungi kompas.com. selesai"
+
+	expected := []string{
+		"Sdr. Budi, S.H. tinggal di Jl. Merdeka no. 5, Bandung.",
+		"Ia membayar Rp. 1.500,50 untuk buku, pena, dll. kemarin.",
+		"Apa kabar?!",
+		"\"Baik,\" katanya.",
+		"Judul tanpa titik",
+		"Dr. Ani berkata: “Saya setuju.”",
+		"Rapat selesai pukul 10.30.",
+		"2020 adalah tahun yang berat…",
+		"Kunjungi kompas.com. selesai",
+	}
+
+	sentences := SplitSentences(text)
+	result := []string{}
+	for _, sentence := range sentences {
+		result = append(result, sentence.Text)
+		if text[sentence.Start:sentence.End] != sentence.Text {
+			t.Errorf("%q doesn't match offsets %d:%d", sentence.Text, sentence.Start, sentence.End)
+		}
+
+		if utf8.RuneCountInString(text[:sentence.Start]) != sentence.StartRune ||
+			sentence.EndRune-sentence.StartRune != utf8.RuneCountInString(sentence.Text) {
+			t.Errorf("%q has wrong rune offsets %d:%d", sentence.Text, sentence.StartRune, sentence.EndRune)
+		}
+	}
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected: %q\nresult:   %q", expected, result)
+	}
+
+	// Custom abbreviations
+	abbreviations := DefaultAbbreviation()
+	abbreviations.Add("kpt")
+	splitter := SentenceSplitter{Abbreviations: abbreviations}
+	result = []string{}
+	for _, sentence := range splitter.Split("Kpt. Andi datang. Kpt. Budi pergi.") {
+		result = append(result, sentence.Text)
+	}
+
+	if expected := []string{"Kpt. Andi datang.", "Kpt. Budi pergi."}; !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected: %q\nresult:   %q", expected, result)
+	}
+
+	if sentences := SplitSentences(" \n\n "); len(sentences) != 0 {
+		t.Errorf("expected no sentence, result: %q", sentences)
+	}
+}
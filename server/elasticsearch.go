@@ -0,0 +1,366 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/RadhiFadlillah/go-sastrawi"
+)
+
+// AnalyzeHandler is http.Handler that accepts the request of Elasticsearch's _analyze
+// API and answers it in the same format, so Sastrawi can be compared side by side with
+// the analyzers of Elasticsearch using the same tools :
+//
+//	{"analyzer": "sastrawi", "text": "..."}
+//	{"tokenizer": "standard", "filter": ["lowercase", "stop"], "text": ["...", "..."]}
+//
+// Analyzer and token filter names are looked up from the registry of Sastrawi, e.g.
+// "simple", "lowercase", "normalize", "stopword" and "stem". Some names of Elasticsearch
+// are accepted as alias, e.g. "stop" and "stemmer". The offsets in response are in UTF-16
+// code units like in Elasticsearch. Request without analyzer and tokenizer, or with
+// analyzer "sastrawi", is analyzed using the analyzer of handler.
+type AnalyzeHandler struct {
+	// MaxBodySize is the maximum size of request body in bytes
+	MaxBodySize int64
+
+	analyzer sastrawi.Analyzer
+	filters  sync.Map
+}
+
+// NewAnalyzeHandler returns AnalyzeHandler with the default limit, which uses analyzer
+// for request without analyzer and stem for "stem" filter. Like in NewHandler, they
+// must be safe to be used by multiple goroutines.
+func NewAnalyzeHandler(stem func(word string) string, analyzer sastrawi.Analyzer) *AnalyzeHandler {
+	handler := &AnalyzeHandler{
+		MaxBodySize: DefaultMaxBodySize,
+		analyzer:    analyzer,
+	}
+
+	handler.filters.Store("stem", sastrawi.StemFilter(stem))
+	return handler
+}
+
+// DefaultAnalyzeHandler returns AnalyzeHandler that uses the default analyzer
+func DefaultAnalyzeHandler() *AnalyzeHandler {
+	stemmer := sastrawi.NewCachedStemmer(sastrawi.NewStemmer(sastrawi.DefaultDictionary()), 10000)
+	return NewAnalyzeHandler(stemmer.Stem, sastrawi.DefaultAnalyzer())
+}
+
+// esTokenizers maps tokenizer name into the matching tokenizer. Tokenizer "sastrawi"
+// is not listed here, since it's the tokenizer of the handler's analyzer.
+var esTokenizers = map[string]sastrawi.Tokenizer{
+	"letter":        {},
+	"standard":      {KeepNumbers: true},
+	"classic":       {KeepNumbers: true, KeepEmails: true},
+	"uax_url_email": {KeepNumbers: true, KeepURLs: true, KeepEmails: true},
+	"icu_tokenizer": {KeepNumbers: true, Unicode: true},
+}
+
+// esFilterAliases maps token filter name of Elasticsearch into the name in Sastrawi's registry
+var esFilterAliases = map[string]string{
+	"stop":              "stopword",
+	"stemmer":           "stem",
+	"indonesian_stem":   "stem",
+	"indonesian_stop":   "stopword",
+	"length":            "min_length",
+	"sastrawi_stem":     "stem",
+	"sastrawi_stopword": "stopword",
+}
+
+// esTypes maps token type into the token type names of Elasticsearch's standard tokenizer
+var esTypes = map[sastrawi.TokenType]string{
+	sastrawi.TokenWord:    "<ALPHANUM>",
+	sastrawi.TokenNumber:  "<NUM>",
+	sastrawi.TokenURL:     "<URL>",
+	sastrawi.TokenEmail:   "<EMAIL>",
+	sastrawi.TokenMention: "<MENTION>",
+	sastrawi.TokenHashtag: "<HASHTAG>",
+	sastrawi.TokenEmoji:   "<EMOJI>",
+	sastrawi.TokenPunct:   "<PUNCT>",
+}
+
+type esAnalyzeRequest struct {
+	Analyzer   string            `json:"analyzer"`
+	Tokenizer  json.RawMessage   `json:"tokenizer"`
+	Filter     []json.RawMessage `json:"filter"`
+	CharFilter []json.RawMessage `json:"char_filter"`
+	Normalizer string            `json:"normalizer"`
+	Explain    bool              `json:"explain"`
+	Text       json.RawMessage   `json:"text"`
+}
+
+// esComponent is tokenizer or token filter that defined inline as object
+type esComponent struct {
+	Type      string   `json:"type"`
+	Stopwords []string `json:"stopwords"`
+	Min       int      `json:"min"`
+}
+
+type esToken struct {
+	Token       string `json:"token"`
+	StartOffset int    `json:"start_offset"`
+	EndOffset   int    `json:"end_offset"`
+	Type        string `json:"type"`
+	Position    int    `json:"position"`
+}
+
+type esAnalyzeResponse struct {
+	Tokens []esToken `json:"tokens"`
+}
+
+type esErrorCause struct {
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+}
+
+type esError struct {
+	RootCause []esErrorCause `json:"root_cause"`
+	esErrorCause
+}
+
+type esErrorResponse struct {
+	Error  esError `json:"error"`
+	Status int     `json:"status"`
+}
+
+// illegalArgumentError is the error that caused by invalid request
+type illegalArgumentError string
+
+func (err illegalArgumentError) Error() string {
+	return string(err)
+}
+
+func (handler *AnalyzeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	handler.serve(w, r, handler.MaxBodySize)
+}
+
+// serve answers the request, whose body is limited to maxBodySize bytes
+func (handler *AnalyzeHandler) serve(w http.ResponseWriter, r *http.Request, maxBodySize int64) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.Header().Set("Allow", "GET, POST")
+		writeESError(w, http.StatusMethodNotAllowed, "method_not_allowed", "Incorrect HTTP method "+r.Method)
+		return
+	}
+
+	var request esAnalyzeRequest
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeESError(w, http.StatusRequestEntityTooLarge, "content_too_long_exception",
+				fmt.Sprintf("request body is larger than %d bytes", maxBytesErr.Limit))
+			return
+		}
+
+		writeESError(w, http.StatusBadRequest, "parse_exception", "failed to parse request body: "+err.Error())
+		return
+	}
+
+	texts, err := request.texts()
+	if err == nil {
+		var analyzer sastrawi.Analyzer
+		if analyzer, err = handler.buildAnalyzer(request); err == nil {
+			writeJSON(w, http.StatusOK, esAnalyzeResponse{Tokens: analyzeES(analyzer, texts)})
+			return
+		}
+	}
+
+	writeESError(w, http.StatusBadRequest, "illegal_argument_exception", err.Error())
+}
+
+// texts returns the text in request, which might be a string or array of strings
+func (request esAnalyzeRequest) texts() ([]string, error) {
+	var text string
+	if err := json.Unmarshal(request.Text, &text); err == nil {
+		return []string{text}, nil
+	}
+
+	var texts []string
+	if err := json.Unmarshal(request.Text, &texts); err != nil || len(texts) == 0 {
+		return nil, illegalArgument("text is missing")
+	}
+
+	return texts, nil
+}
+
+// buildAnalyzer builds the analyzer that described by request
+func (handler *AnalyzeHandler) buildAnalyzer(request esAnalyzeRequest) (sastrawi.Analyzer, error) {
+	switch {
+	case request.Explain:
+		return sastrawi.Analyzer{}, illegalArgument("explain is not supported")
+	case len(request.CharFilter) > 0:
+		return sastrawi.Analyzer{}, illegalArgument("char_filter is not supported")
+	case request.Normalizer != "":
+		return sastrawi.Analyzer{}, illegalArgument("normalizer is not supported")
+	}
+
+	if request.Analyzer != "" {
+		if request.Tokenizer != nil || request.Filter != nil {
+			return sastrawi.Analyzer{}, illegalArgument(
+				"tokenizer/filter can't be provided with analyzer")
+		}
+
+		if request.Analyzer == "sastrawi" {
+			return handler.analyzer, nil
+		}
+
+		analyzer, err := sastrawi.NewAnalyzer(request.Analyzer)
+		if err != nil {
+			return sastrawi.Analyzer{}, illegalArgument("failed to find analyzer [%s]", request.Analyzer)
+		}
+
+		return analyzer, nil
+	}
+
+	if request.Tokenizer == nil && request.Filter == nil {
+		return handler.analyzer, nil
+	}
+
+	analyzer := sastrawi.Analyzer{}
+	if request.Tokenizer != nil {
+		component, err := parseComponent(request.Tokenizer)
+		if err != nil {
+			return sastrawi.Analyzer{}, err
+		}
+
+		tokenizer, exist := esTokenizers[component.Type]
+		if component.Type == "sastrawi" {
+			tokenizer, exist = handler.analyzer.Tokenizer, true
+		}
+
+		if !exist {
+			return sastrawi.Analyzer{}, illegalArgument("failed to find tokenizer [%s]", component.Type)
+		}
+
+		analyzer.Tokenizer = tokenizer
+	}
+
+	for _, raw := range request.Filter {
+		component, err := parseComponent(raw)
+		if err != nil {
+			return sastrawi.Analyzer{}, err
+		}
+
+		filter, err := handler.filter(component)
+		if err != nil {
+			return sastrawi.Analyzer{}, err
+		}
+
+		analyzer.Filters = append(analyzer.Filters, filter)
+	}
+
+	return analyzer, nil
+}
+
+// parseComponent parses tokenizer or token filter, which might be its name or an object
+func parseComponent(raw json.RawMessage) (esComponent, error) {
+	var component esComponent
+	if err := json.Unmarshal(raw, &component.Type); err == nil {
+		return component, nil
+	}
+
+	if err := json.Unmarshal(raw, &component); err != nil || component.Type == "" {
+		return component, illegalArgument("invalid tokenizer or filter %s", raw)
+	}
+
+	return component, nil
+}
+
+// filter returns the token filter that described by component. The filters that
+// created by name are cached, so they are only created once.
+func (handler *AnalyzeHandler) filter(component esComponent) (sastrawi.TokenFilter, error) {
+	name := component.Type
+	if alias, exist := esFilterAliases[name]; exist {
+		name = alias
+	}
+
+	switch {
+	case name == "stopword" && component.Stopwords != nil:
+		return sastrawi.StopwordFilter(sastrawi.NewDictionary(component.Stopwords...)), nil
+	case name == "min_length" && component.Min > 0:
+		return sastrawi.MinLengthFilter(component.Min), nil
+	}
+
+	if filter, cached := handler.filters.Load(name); cached {
+		return filter.(sastrawi.TokenFilter), nil
+	}
+
+	filter, err := sastrawi.NewTokenFilter(name)
+	if err != nil {
+		return nil, illegalArgument("failed to find filter under name [%s]", component.Type)
+	}
+
+	cached, _ := handler.filters.LoadOrStore(name, filter)
+	return cached.(sastrawi.TokenFilter), nil
+}
+
+// analyzeES analyzes texts like Elasticsearch does for array of texts, i.e. the offsets
+// and positions of each text continue from the previous one, with offset gap 1
+func analyzeES(analyzer sastrawi.Analyzer, texts []string) []esToken {
+	result := []esToken{}
+	offset, position := 0, 0
+	for _, text := range texts {
+		tokens := analyzer.Tokenizer.TokenizeWithOffsets(text)
+		nTokens := len(tokens)
+		for _, filter := range analyzer.Filters {
+			tokens = filter.Filter(tokens)
+		}
+
+		counter := utf16Counter{text: text}
+		for _, token := range tokens {
+			result = append(result, esToken{
+				Token:       token.Text,
+				StartOffset: offset + counter.at(token.Start),
+				EndOffset:   offset + counter.at(token.End),
+				Type:        esTypes[token.Type],
+				Position:    position + token.Position,
+			})
+		}
+
+		offset += counter.at(len(text)) + 1
+		position += nTokens
+	}
+
+	return result
+}
+
+// utf16Counter converts byte offsets in text into UTF-16 offsets. It's fastest when
+// the byte offsets are requested in increasing order.
+type utf16Counter struct {
+	text       string
+	byteOffset int
+	offset     int
+}
+
+func (counter *utf16Counter) at(byteOffset int) int {
+	if byteOffset < counter.byteOffset {
+		counter.byteOffset, counter.offset = 0, 0
+	}
+
+	for counter.byteOffset < byteOffset {
+		r, size := utf8.DecodeRuneInString(counter.text[counter.byteOffset:])
+		counter.byteOffset += size
+		counter.offset++
+		if r > 0xFFFF {
+			counter.offset++
+		}
+	}
+
+	return counter.offset
+}
+
+func illegalArgument(format string, args ...any) error {
+	return illegalArgumentError(fmt.Sprintf(format, args...))
+}
+
+func writeESError(w http.ResponseWriter, status int, errType, reason string) {
+	cause := esErrorCause{Type: errType, Reason: reason}
+	writeJSON(w, status, esErrorResponse{
+		Error:  esError{RootCause: []esErrorCause{cause}, esErrorCause: cause},
+		Status: status,
+	})
+}
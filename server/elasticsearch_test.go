@@ -0,0 +1,101 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/RadhiFadlillah/go-sastrawi"
+)
+
+func TestAnalyzeHandler(t *testing.T) {
+	handler := DefaultAnalyzeHandler()
+	handler.MaxBodySize = 512
+
+	testItems := []struct {
+		body     string
+		status   int
+		expected string
+	}{
+		{
+			`{"analyzer":"sastrawi","text":"😀 Perekonomian yang membanggakan"}`, 200,
+			`{"tokens":[{"token":"ekonomi","start_offset":3,"end_offset":15,"type":"<ALPHANUM>","position":0},` +
+				`{"token":"bangga","start_offset":21,"end_offset":33,"type":"<ALPHANUM>","position":2}]}`,
+		},
+		{
+			`{"tokenizer":"uax_url_email","filter":["lowercase",{"type":"stop","stopwords":["di"]},"stemmer"],` +
+				`"text":["Baca di http://kompas.com","Tahun 2020"]}`, 200,
+			`{"tokens":[{"token":"baca","start_offset":0,"end_offset":4,"type":"<ALPHANUM>","position":0},` +
+				`{"token":"http://kompas.com","start_offset":8,"end_offset":25,"type":"<URL>","position":2},` +
+				`{"token":"tahun","start_offset":26,"end_offset":31,"type":"<ALPHANUM>","position":3},` +
+				`{"token":"2020","start_offset":32,"end_offset":36,"type":"<NUM>","position":4}]}`,
+		},
+		{
+			`{"tokenizer":"sastrawi","text":"Tahun 2020"}`, 200,
+			`{"tokens":[{"token":"tahun","start_offset":0,"end_offset":5,"type":"<ALPHANUM>","position":0},` +
+				`{"token":"2020","start_offset":6,"end_offset":10,"type":"<NUM>","position":1}]}`,
+		},
+		{
+			`{"text":"yg dimakan"}`, 200,
+			`{"tokens":[{"token":"makan","start_offset":3,"end_offset":10,"type":"<ALPHANUM>","position":1}]}`,
+		},
+		{
+			`{"analyzer":"simple","filter":["stop"],"text":"x"}`, 400,
+			`{"error":{"root_cause":[{"type":"illegal_argument_exception","reason":"tokenizer/filter can't be provided with analyzer"}],` +
+				`"type":"illegal_argument_exception","reason":"tokenizer/filter can't be provided with analyzer"},"status":400}`,
+		},
+		{
+			`{"analyzer":"indonesian","text":"x"}`, 400,
+			`{"error":{"root_cause":[{"type":"illegal_argument_exception","reason":"failed to find analyzer [indonesian]"}],` +
+				`"type":"illegal_argument_exception","reason":"failed to find analyzer [indonesian]"},"status":400}`,
+		},
+		{
+			`{"filter":["unknown"],"text":"x"}`, 400,
+			`{"error":{"root_cause":[{"type":"illegal_argument_exception","reason":"failed to find filter under name [unknown]"}],` +
+				`"type":"illegal_argument_exception","reason":"failed to find filter under name [unknown]"},"status":400}`,
+		},
+		{
+			`{"analyzer":"sastrawi"}`, 400,
+			`{"error":{"root_cause":[{"type":"illegal_argument_exception","reason":"text is missing"}],` +
+				`"type":"illegal_argument_exception","reason":"text is missing"},"status":400}`,
+		},
+	}
+
+	for _, item := range testItems {
+		request := httptest.NewRequest(http.MethodPost, "/_analyze", strings.NewReader(item.body))
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+
+		if recorder.Code != item.status {
+			t.Errorf("%s, expected status %d, result: %d", item.body, item.status, recorder.Code)
+		}
+
+		if result := strings.TrimSpace(recorder.Body.String()); result != item.expected {
+			t.Errorf("%s\nexpected: %s\nresult:   %s", item.body, item.expected, result)
+		}
+	}
+
+	// The main handler also serves _analyze, with GET like in Elasticsearch
+	recorder := httptest.NewRecorder()
+	DefaultHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/_analyze",
+		strings.NewReader(`{"analyzer":"simple","text":"Halo"}`)))
+	if recorder.Code != 200 || !strings.Contains(recorder.Body.String(), `"token":"halo"`) {
+		t.Errorf("expected token halo, result: %d %s", recorder.Code, recorder.Body)
+	}
+
+	// The main handler uses its own analyzer and stemmer for _analyze
+	stemmer := sastrawi.NewStemmer(sastrawi.NewDictionary("baca"))
+	analyzer := sastrawi.Analyzer{Filters: []sastrawi.TokenFilter{sastrawi.StemFilter(stemmer.Stem)}}
+	mainHandler := NewHandler(stemmer.Stem, analyzer)
+	for _, body := range []string{`{"text":"Membaca bukunya"}`, `{"filter":["stem"],"text":"Membaca bukunya"}`} {
+		recorder = httptest.NewRecorder()
+		mainHandler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/_analyze", strings.NewReader(body)))
+
+		expected := `{"tokens":[{"token":"baca","start_offset":0,"end_offset":7,"type":"<ALPHANUM>","position":0},` +
+			`{"token":"bukunya","start_offset":8,"end_offset":15,"type":"<ALPHANUM>","position":1}]}`
+		if result := strings.TrimSpace(recorder.Body.String()); result != expected {
+			t.Errorf("%s\nexpected: %s\nresult:   %s", body, expected, result)
+		}
+	}
+}
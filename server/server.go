@@ -0,0 +1,249 @@
+// Package server provides http.Handler that exposes the stemmer, tokenizer and
+// analyzer of Sastrawi as JSON API, so they can be used by programs that not
+// written in Go.
+//
+// All endpoints except /healthz accept POST request with JSON body :
+//
+//	POST /stem      {"word": "menahan"}              => {"word": "menahan", "root": "tahan"}
+//	POST /stem      {"words": ["menahan", ...]}      => {"results": [{"word": ..., "root": ...}, ...]}
+//	POST /tokenize  {"text": "..."}                  => {"tokens": [...]}
+//	POST /tokenize  {"texts": ["...", ...]}          => {"results": [{"tokens": [...]}, ...]}
+//	POST /analyze   {"text": "..."}                  => {"tokens": [...]}
+//	POST /analyze   {"texts": ["...", ...]}          => {"results": [{"tokens": [...]}, ...]}
+//	GET  /healthz                                    => {"status": "ok"}
+//
+// It also serves /_analyze that compatible with Elasticsearch, see AnalyzeHandler.
+//
+// Failed request is answered with {"error": "..."} and the matching status code.
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/RadhiFadlillah/go-sastrawi"
+)
+
+// Default limits of Handler
+const (
+	DefaultMaxBodySize  = 1 << 20
+	DefaultMaxBatchSize = 1000
+)
+
+// Handler is http.Handler for Sastrawi JSON API
+type Handler struct {
+	// MaxBodySize is the maximum size of request body in bytes
+	MaxBodySize int64
+
+	// MaxBatchSize is the maximum number of words or texts in a batch request
+	MaxBatchSize int
+
+	stem      func(word string) string
+	analyzer  sastrawi.Analyzer
+	esHandler *AnalyzeHandler
+	mux       *http.ServeMux
+}
+
+// NewHandler returns Handler that uses stem for /stem, analyzer for /analyze and the
+// tokenizer of analyzer for /tokenize. stem and analyzer must be safe to be used by
+// multiple goroutines, e.g. the Stem method of Stemmer or CachedStemmer.
+func NewHandler(stem func(word string) string, analyzer sastrawi.Analyzer) *Handler {
+	handler := &Handler{
+		MaxBodySize:  DefaultMaxBodySize,
+		MaxBatchSize: DefaultMaxBatchSize,
+		stem:         stem,
+		analyzer:     analyzer,
+		esHandler:    NewAnalyzeHandler(stem, analyzer),
+		mux:          http.NewServeMux(),
+	}
+
+	handler.mux.HandleFunc("/stem", handler.post(handler.serveStem))
+	handler.mux.HandleFunc("/tokenize", handler.post(handler.serveTokenize))
+	handler.mux.HandleFunc("/analyze", handler.post(handler.serveAnalyze))
+	handler.mux.HandleFunc("/healthz", handler.serveHealth)
+	handler.mux.HandleFunc("/_analyze", func(w http.ResponseWriter, r *http.Request) {
+		handler.esHandler.serve(w, r, handler.MaxBodySize)
+	})
+	handler.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "not found")
+	})
+
+	return handler
+}
+
+// DefaultHandler returns Handler that uses cached stemmer with the default dictionary,
+// and the default analyzer
+func DefaultHandler() *Handler {
+	stemmer := sastrawi.NewCachedStemmer(sastrawi.NewStemmer(sastrawi.DefaultDictionary()), 10000)
+	return NewHandler(stemmer.Stem, sastrawi.DefaultAnalyzer())
+}
+
+func (handler *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	handler.mux.ServeHTTP(w, r)
+}
+
+type stemRequest struct {
+	Word  *string  `json:"word"`
+	Words []string `json:"words"`
+}
+
+type stemResult struct {
+	Word string `json:"word"`
+	Root string `json:"root"`
+}
+
+type textRequest struct {
+	Text  *string  `json:"text"`
+	Texts []string `json:"texts"`
+}
+
+type tokensResult struct {
+	Tokens []sastrawi.Token `json:"tokens"`
+}
+
+type batchResponse[T any] struct {
+	Results []T `json:"results"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func (handler *Handler) serveStem(w http.ResponseWriter, r *http.Request) {
+	var request stemRequest
+	if !handler.decode(w, r, &request) {
+		return
+	}
+
+	if (request.Word == nil) == (request.Words == nil) {
+		writeError(w, http.StatusBadRequest, `request must have either "word" or "words"`)
+		return
+	}
+
+	if request.Word != nil {
+		writeJSON(w, http.StatusOK, stemResult{Word: *request.Word, Root: handler.stem(*request.Word)})
+		return
+	}
+
+	if !handler.checkBatch(w, len(request.Words)) {
+		return
+	}
+
+	results := make([]stemResult, len(request.Words))
+	for i, word := range request.Words {
+		results[i] = stemResult{Word: word, Root: handler.stem(word)}
+	}
+
+	writeJSON(w, http.StatusOK, batchResponse[stemResult]{Results: results})
+}
+
+func (handler *Handler) serveTokenize(w http.ResponseWriter, r *http.Request) {
+	handler.serveText(w, r, handler.analyzer.Tokenizer.TokenizeWithOffsets)
+}
+
+func (handler *Handler) serveAnalyze(w http.ResponseWriter, r *http.Request) {
+	handler.serveText(w, r, handler.analyzer.Analyze)
+}
+
+// serveText answers text request by converting each text into tokens using fn
+func (handler *Handler) serveText(w http.ResponseWriter, r *http.Request, fn func(string) []sastrawi.Token) {
+	var request textRequest
+	if !handler.decode(w, r, &request) {
+		return
+	}
+
+	if (request.Text == nil) == (request.Texts == nil) {
+		writeError(w, http.StatusBadRequest, `request must have either "text" or "texts"`)
+		return
+	}
+
+	if request.Text != nil {
+		writeJSON(w, http.StatusOK, tokensResult{Tokens: fn(*request.Text)})
+		return
+	}
+
+	if !handler.checkBatch(w, len(request.Texts)) {
+		return
+	}
+
+	results := make([]tokensResult, len(request.Texts))
+	for i, text := range request.Texts {
+		results[i] = tokensResult{Tokens: fn(text)}
+	}
+
+	writeJSON(w, http.StatusOK, batchResponse[tokensResult]{Results: results})
+}
+
+func (handler *Handler) serveHealth(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// post wraps fn so it only accepts POST request
+func (handler *Handler) post(fn http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+
+		fn(w, r)
+	}
+}
+
+// decode decodes JSON body of r into dst. If it fails, the error is written to w.
+func (handler *Handler) decode(w http.ResponseWriter, r *http.Request, dst any) bool {
+	r.Body = http.MaxBytesReader(w, r.Body, handler.MaxBodySize)
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	err := decoder.Decode(dst)
+	if err == nil && decoder.More() {
+		err = errors.New("body must only contain a single JSON object")
+	}
+
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case err == nil:
+		return true
+	case errors.As(err, &maxBytesErr):
+		writeError(w, http.StatusRequestEntityTooLarge,
+			fmt.Sprintf("request body is larger than %d bytes", maxBytesErr.Limit))
+	default:
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+	}
+
+	return false
+}
+
+// checkBatch checks if batch with n items is allowed. If not, the error is written to w.
+func (handler *Handler) checkBatch(w http.ResponseWriter, n int) bool {
+	if handler.MaxBatchSize > 0 && n > handler.MaxBatchSize {
+		writeError(w, http.StatusRequestEntityTooLarge,
+			fmt.Sprintf("batch has %d items, the maximum is %d", n, handler.MaxBatchSize))
+		return false
+	}
+
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(value)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{Error: message})
+}
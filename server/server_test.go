@@ -0,0 +1,68 @@
+package server
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandler(t *testing.T) {
+	handler := DefaultHandler()
+	handler.MaxBodySize = 256
+	handler.MaxBatchSize = 2
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	testItems := []struct {
+		method   string
+		path     string
+		body     string
+		status   int
+		expected string
+	}{
+		{"POST", "/stem", `{"word":"menahan"}`, 200, `{"word":"menahan","root":"tahan"}`},
+		{"POST", "/stem", `{"words":["menahan","pewarna"]}`, 200,
+			`{"results":[{"word":"menahan","root":"tahan"},{"word":"pewarna","root":"warna"}]}`},
+		{"POST", "/tokenize", `{"text":"Ibu &amp; Ayah"}`, 200,
+			`{"tokens":[{"text":"ibu","type":"WORD","surface":"Ibu","start":0,"end":3,"start_rune":0,"end_rune":3,"position":0},` +
+				`{"text":"ayah","type":"WORD","surface":"Ayah","start":10,"end":14,"start_rune":10,"end_rune":14,"position":1}]}`},
+		{"POST", "/analyze", `{"texts":["yg membanggakan",""]}`, 200,
+			`{"results":[{"tokens":[{"text":"bangga","type":"WORD","surface":"membanggakan","start":3,"end":15,"start_rune":3,"end_rune":15,"position":1}]},{"tokens":[]}]}`},
+		{"GET", "/healthz", "", 200, `{"status":"ok"}`},
+		{"GET", "/stem", "", 405, `{"error":"method not allowed"}`},
+		{"POST", "/healthz", "", 405, `{"error":"method not allowed"}`},
+		{"POST", "/unknown", "", 404, `{"error":"not found"}`},
+		{"POST", "/stem", `{"words":["a","b","c"]}`, 413, `{"error":"batch has 3 items, the maximum is 2"}`},
+		{"POST", "/stem", `{"word":"` + strings.Repeat("a", 300) + `"}`, 413, `{"error":"request body is larger than 256 bytes"}`},
+		{"POST", "/stem", `{"word":"a","words":["b"]}`, 400, `{"error":"request must have either \"word\" or \"words\""}`},
+		{"POST", "/analyze", `{}`, 400, `{"error":"request must have either \"text\" or \"texts\""}`},
+		{"POST", "/stem", `{"kata":"makan"}`, 400, `{"error":"invalid request body: json: unknown field \"kata\""}`},
+		{"POST", "/stem", `{"word":"a"}{"word":"b"}`, 400, `{"error":"invalid request body: body must only contain a single JSON object"}`},
+	}
+
+	for _, item := range testItems {
+		request, err := http.NewRequest(item.method, server.URL+item.path, strings.NewReader(item.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		response, err := http.DefaultClient.Do(request)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		body, _ := io.ReadAll(response.Body)
+		response.Body.Close()
+
+		if response.StatusCode != item.status {
+			t.Errorf("%s %s, expected status %d, result: %d", item.method, item.path, item.status, response.StatusCode)
+		}
+
+		if result := strings.TrimSpace(string(body)); result != item.expected {
+			t.Errorf("%s %s %s\nexpected: %s\nresult:   %s", item.method, item.path, item.body, item.expected, result)
+		}
+	}
+}
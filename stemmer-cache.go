@@ -0,0 +1,169 @@
+package sastrawi
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+)
+
+// CachedStemmer is Stemmer that remembers the root of recently stemmed words.
+// It's safe to be used by multiple goroutines.
+//
+// The cache is cleared when its dictionary changed using ChangeDictionary, and
+// when words added to or removed from its dictionary. The changes are detected for
+// SyncDictionary and DictionaryView, e.g. the one returned by DefaultDictionary, and
+// LayeredDictionary and MultiLookup that consist of them, since they count their own
+// changes. After modifying Dictionary or other RootLookup implementations, Purge
+// must be called manually.
+type CachedStemmer struct {
+	mutex    sync.Mutex
+	stemmer  Stemmer
+	capacity int
+	version  uint64
+	purged   uint64
+	entries  map[string]*list.Element
+	recent   *list.List
+	hits     uint64
+	misses   uint64
+}
+
+// CacheStats is the usage statistic of CachedStemmer
+type CacheStats struct {
+	Hits   uint64
+	Misses uint64
+	Size   int
+}
+
+type cacheEntry struct {
+	word string
+	root string
+}
+
+// NewCachedStemmer returns new CachedStemmer that remembers at most capacity words.
+// If capacity is less than 1, it will be set to 1.
+func NewCachedStemmer(stemmer Stemmer, capacity int) *CachedStemmer {
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	return &CachedStemmer{
+		stemmer:  stemmer,
+		capacity: capacity,
+		version:  lookupVersion(stemmer.dictionary),
+		entries:  make(map[string]*list.Element),
+		recent:   list.New(),
+	}
+}
+
+// Stem reduces inflected or derived word to its root form,
+// using the cached result if the word has been stemmed before
+func (cache *CachedStemmer) Stem(word string) string {
+	// The stemmer is case insensitive, so the words share the same entry
+	word = strings.ToLower(word)
+
+	cache.mutex.Lock()
+	version := lookupVersion(cache.stemmer.dictionary)
+	if version != cache.version {
+		cache.purge()
+		cache.version = version
+	}
+
+	if element, found := cache.entries[word]; found {
+		cache.hits++
+		cache.recent.MoveToFront(element)
+		root := element.Value.(*cacheEntry).root
+		cache.mutex.Unlock()
+		return root
+	}
+
+	cache.misses++
+	stemmer := cache.stemmer
+	purged := cache.purged
+	cache.mutex.Unlock()
+
+	root := stemmer.Stem(word)
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	// Don't save the result if cache purged or dictionary changed while stemming
+	if purged != cache.purged || version != lookupVersion(cache.stemmer.dictionary) {
+		return root
+	}
+
+	if _, found := cache.entries[word]; found {
+		return root
+	}
+
+	cache.entries[word] = cache.recent.PushFront(&cacheEntry{word: word, root: root})
+	if cache.recent.Len() > cache.capacity {
+		oldest := cache.recent.Back()
+		cache.recent.Remove(oldest)
+		delete(cache.entries, oldest.Value.(*cacheEntry).word)
+	}
+
+	return root
+}
+
+// ChangeDictionary changes dictionary that used in stemmer, then clears the cache
+func (cache *CachedStemmer) ChangeDictionary(dict RootLookup) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	cache.stemmer.ChangeDictionary(dict)
+	cache.version = lookupVersion(dict)
+	cache.purge()
+}
+
+// Purge removes all cached words
+func (cache *CachedStemmer) Purge() {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	cache.purge()
+}
+
+// Stats returns the number of cache hits and misses, and the number of cached words
+func (cache *CachedStemmer) Stats() CacheStats {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	return CacheStats{
+		Hits:   cache.hits,
+		Misses: cache.misses,
+		Size:   cache.recent.Len(),
+	}
+}
+
+func (cache *CachedStemmer) purge() {
+	cache.purged++
+	cache.entries = make(map[string]*list.Element)
+	cache.recent.Init()
+}
+
+// lookupVersion returns number that changes every time words in lookup changed,
+// or 0 if lookup doesn't report its changes
+func lookupVersion(lookup RootLookup) uint64 {
+	switch lookup := lookup.(type) {
+	case *SyncDictionary:
+		return lookup.version.Load()
+	case *DictionaryView:
+		return lookup.version
+	case LayeredDictionary:
+		version := uint64(0)
+		for _, layer := range lookup {
+			version += lookupVersion(layer.Allow) + lookupVersion(layer.Deny)
+		}
+
+		return version
+	case MultiLookup:
+		version := uint64(0)
+		for _, sublookup := range lookup {
+			version += lookupVersion(sublookup)
+		}
+
+		return version
+	}
+
+	return 0
+}
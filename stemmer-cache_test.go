@@ -0,0 +1,118 @@
+package sastrawi
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestCachedStemmer(t *testing.T) {
+	dictionary := NewSyncDictionary(NewDictionary("makan", "minum", "tidur"))
+	cache := NewCachedStemmer(NewStemmer(dictionary), 2)
+
+	for _, item := range []testItem{
+		{value: "makanan", expected: "makan"},
+		{value: "makanan", expected: "makan"},
+		{value: "meminum", expected: "minum"},
+		{value: "tertidur", expected: "tidur"},
+		{value: "makanan", expected: "makan"},
+	} {
+		result := cache.Stem(item.value)
+		if result != item.expected {
+			t.Errorf("%s, expected: %s, result: %s", item.value, item.expected, result)
+		}
+	}
+
+	// "makanan" is evicted by "tertidur", so only the second call is a hit
+	stats := cache.Stats()
+	if stats.Hits != 1 || stats.Misses != 4 || stats.Size != 2 {
+		t.Errorf("expected 1 hit, 4 misses and 2 words, result: %+v", stats)
+	}
+
+	// Cache must be cleared when dictionary modified
+	dictionary.Remove("makan")
+	if result := cache.Stem("makanan"); result != "makanan" {
+		t.Errorf("makanan after root removed, expected: makanan, result: %s", result)
+	}
+
+	plain := NewDictionary("makan")
+	cache.ChangeDictionary(plain)
+	if result := cache.Stem("makanan"); result != "makan" {
+		t.Errorf("makanan after dictionary changed, expected: makan, result: %s", result)
+	}
+
+	if stats := cache.Stats(); stats.Size != 1 {
+		t.Errorf("expected 1 word after dictionary changed, result: %d", stats.Size)
+	}
+
+	// Changes of plain Dictionary are not tracked, so it must be purged manually
+	plain.Remove("makan")
+	cache.Purge()
+	if result := cache.Stem("makanan"); result != "makanan" {
+		t.Errorf("makanan after purged, expected: makanan, result: %s", result)
+	}
+}
+
+func TestCachedStemmerInvalidation(t *testing.T) {
+	dictionary := NewDictionary("makan")
+	cache := NewCachedStemmer(NewStemmer(dictionary), 10)
+	cache.Stem("makanan")
+
+	// Words that only differ in case share the same entry
+	if result := cache.Stem("Makanan"); result != "makan" || cache.Stats().Hits != 1 {
+		t.Errorf("Makanan, expected cache hit with root makan, result: %s, %+v", result, cache.Stats())
+	}
+
+	// Modifying unrelated dictionaries doesn't clear the cache
+	other := NewDictionary("minum")
+	other.Add("tidur")
+	var decoded Dictionary
+	decoded.UnmarshalText([]byte("jalan\n"))
+	NewSyncDictionary(other).Replace(NewDictionary("lari"))
+	if stats := cache.Stats(); stats.Size != 1 {
+		t.Errorf("expected cached word kept, result: %+v", stats)
+	}
+
+	// Modifying any layer of its dictionary clears the cache
+	synced := NewSyncDictionary(NewDictionary("minum"))
+	deny := NewSyncDictionary(NewDictionary())
+	cache.ChangeDictionary(LayeredDictionary{{Allow: dictionary}, {Allow: synced, Deny: deny}})
+	for _, item := range []struct {
+		modify   func()
+		word     string
+		expected string
+	}{
+		{func() {}, "meminum", "minum"},
+		{func() { synced.Remove("minum") }, "meminum", "meminum"},
+		{func() { deny.Add("makan") }, "makanan", "makanan"},
+		{func() { dictionary.Remove("makan"); deny.Remove("makan"); synced.Add("makan") }, "makanan", "makan"},
+	} {
+		cache.Stem(item.word)
+		item.modify()
+		if result := cache.Stem(item.word); result != item.expected {
+			t.Errorf("%s, expected: %s, result: %s", item.word, item.expected, result)
+		}
+	}
+}
+
+func TestCachedStemmerConcurrent(t *testing.T) {
+	dictionary := NewDictionary("makan", "minum", "tidur", "jalan")
+	cache := NewCachedStemmer(NewStemmer(dictionary), 3)
+	words := []string{"makanan", "meminum", "tertidur", "berjalan", "dimakan"}
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				cache.Stem(words[j%len(words)])
+			}
+		}()
+	}
+	wg.Wait()
+
+	stats := cache.Stats()
+	if stats.Hits+stats.Misses != 8000 {
+		t.Errorf("expected 8000 lookups, result: %d", stats.Hits+stats.Misses)
+	}
+}
@@ -0,0 +1,124 @@
+package sastrawi
+
+import (
+	"strings"
+)
+
+// analyzeReduplication finds the root that shared by both halves of reduplicated word.
+// It handles full reduplication (anak-anak), affixed reduplication (buku-bukunya,
+// berlari-lari, meniru-nirukan) and rhyming reduplication (sayur-mayur, bolak-balik).
+func (stemmer Stemmer) analyzeReduplication(word, first, second string, trace *Trace) Analysis {
+	trace.add(TraceStep{Action: TraceReduplication, Input: word, Output: first, Rule: second})
+
+	firstAnalysis := stemmer.analyze(first, trace)
+	secondAnalysis := stemmer.analyze(second, trace)
+
+	// The second half might lost its prefix, e.g. meniru-nirukan
+	if !secondAnalysis.Found {
+		if analysis := stemmer.analyze("me"+second, trace); analysis.Found {
+			secondAnalysis = analysis
+		}
+	}
+
+	var result Analysis
+	switch {
+	case firstAnalysis.Root == secondAnalysis.Root:
+		result = firstAnalysis
+		if secondAnalysis.countAffixes() > firstAnalysis.countAffixes() {
+			result = secondAnalysis
+		}
+
+	case isConsonantRhyme(firstAnalysis.Root, secondAnalysis.Root):
+		// Consonant changed, e.g. sayur-mayur, the root is usually the first half
+		result = firstFound(firstAnalysis, secondAnalysis)
+
+	case isVowelRhyme(firstAnalysis.Root, secondAnalysis.Root):
+		// Vowel changed, e.g. bolak-balik, the root is usually the second half
+		result = firstFound(secondAnalysis, firstAnalysis)
+
+	default:
+		return Analysis{Word: word, Root: word}
+	}
+
+	// Like the other words, the original word is returned if no root found
+	if !result.Found {
+		return Analysis{Word: word, Root: word}
+	}
+
+	result.Word = word
+	result.Reduplicated = true
+	return result
+}
+
+// splitReduplication splits reduplicated word, e.g. "anak-anak" or
+// "malaikat-malaikat-nya", into its halves. Word that only has hyphenated
+// particle or possessive like "kuasa-mu" is not reduplicated word.
+func splitReduplication(word string) (string, string, bool) {
+	separator := strings.LastIndexByte(word, '-')
+	if separator < 0 {
+		return "", "", false
+	}
+
+	first, second := word[:separator], word[separator+1:]
+	switch second {
+	case "ku", "mu", "nya", "lah", "kah", "tah", "pun":
+		separator = strings.LastIndexByte(first, '-')
+		if separator < 0 {
+			return "", "", false
+		}
+
+		first, second = first[:separator], first[separator+1:]+"-"+second
+	}
+
+	if first == "" || second == "" {
+		return "", "", false
+	}
+
+	return first, second, true
+}
+
+// isConsonantRhyme checks if a and b only differ in their first character, e.g. sayur and mayur
+func isConsonantRhyme(a, b string) bool {
+	return len(a) == len(b) && len(a) > 1 && a[0] != b[0] && a[1:] == b[1:]
+}
+
+// isVowelRhyme checks if a and b only differ in their vowels, e.g. bolak and balik
+func isVowelRhyme(a, b string) bool {
+	if len(a) != len(b) || a == b {
+		return false
+	}
+
+	for i := 0; i < len(a); i++ {
+		if isVowel(a, i) != isVowel(b, i) {
+			return false
+		}
+
+		if !isVowel(a, i) && a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// firstFound returns the first analysis whose root exists in dictionary
+func firstFound(analyses ...Analysis) Analysis {
+	for _, analysis := range analyses {
+		if analysis.Found {
+			return analysis
+		}
+	}
+
+	return analyses[0]
+}
+
+func (analysis Analysis) countAffixes() int {
+	nAffixes := len(analysis.Prefixes)
+	for _, affix := range []string{analysis.Infix, analysis.Suffix, analysis.Possessive, analysis.Particle} {
+		if affix != "" {
+			nAffixes++
+		}
+	}
+
+	return nAffixes
+}
@@ -0,0 +1,171 @@
+package sastrawi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TraceAction is the kind of step that done while stemming a word
+type TraceAction string
+
+// List of actions that recorded in Trace
+const (
+	// TraceBranch is recorded when stemmer decides whether prefix or suffix removed first
+	TraceBranch TraceAction = "branch"
+
+	// TraceLookup is recorded every time a candidate checked against dictionary
+	TraceLookup TraceAction = "lookup"
+
+	// TraceRemoveSuffix is recorded when particle, possessive or suffix removed
+	TraceRemoveSuffix TraceAction = "remove-suffix"
+
+	// TraceRemovePrefix is recorded when a prefix pattern applied
+	TraceRemovePrefix TraceAction = "remove-prefix"
+
+	// TraceRemoveInfix is recorded when an infix pattern applied
+	TraceRemoveInfix TraceAction = "remove-infix"
+
+	// TraceRecoding is recorded when a recoding character tried in front of the word
+	TraceRecoding TraceAction = "recoding"
+
+	// TraceRestoreSuffix is recorded for each combination tried by loopPengembalianAkhiran
+	TraceRestoreSuffix TraceAction = "restore-suffix"
+
+	// TraceReduplication is recorded when reduplicated word split into its halves
+	TraceReduplication TraceAction = "reduplication"
+)
+
+// TraceStep is a single step that done while stemming a word
+type TraceStep struct {
+	// Action is the kind of this step
+	Action TraceAction
+
+	// Rule describes what applied in this step, e.g. "prefix-first" for
+	// TraceBranch, "me-4" for TraceRemovePrefix, "infix-1" for TraceRemoveInfix,
+	// "particle" for TraceRemoveSuffix, the recoding character for TraceRecoding
+	// or the restored suffixes for TraceRestoreSuffix, or the second half for
+	// TraceReduplication
+	Rule string
+
+	// Input is the word before this step
+	Input string
+
+	// Output is the word after this step, or the first half for TraceReduplication
+	Output string
+
+	// Found is true if the word checked in this step exists in dictionary
+	Found bool
+}
+
+// Trace is the record of every step that done while stemming a word
+type Trace struct {
+	Word  string
+	Root  string
+	Found bool
+	Steps []TraceStep
+}
+
+// StemTrace is like Analyze, but also records every step that done while stemming word
+func (stemmer Stemmer) StemTrace(word string) (Analysis, Trace) {
+	trace := Trace{Word: strings.ToLower(word)}
+	analysis := stemmer.analyze(word, &trace)
+	analysis.trace = nil
+
+	trace.Root = analysis.Root
+	trace.Found = analysis.Found
+	return analysis, trace
+}
+
+// String returns the human readable rendering of trace
+func (trace Trace) String() string {
+	sb := strings.Builder{}
+	fmt.Fprintf(&sb, "stem %q\n", trace.Word)
+
+	for i, step := range trace.Steps {
+		fmt.Fprintf(&sb, "%3d. ", i+1)
+
+		switch step.Action {
+		case TraceBranch:
+			fmt.Fprintf(&sb, "branch %s: %q", step.Rule, step.Input)
+		case TraceLookup:
+			fmt.Fprintf(&sb, "lookup %q: %s", step.Input, foundString(step.Found))
+		case TraceRemoveSuffix:
+			fmt.Fprintf(&sb, "remove %s: %q => %q", step.Rule, step.Input, step.Output)
+		case TraceRemovePrefix:
+			fmt.Fprintf(&sb, "remove prefix %s: %q => %q", step.Rule, step.Input, step.Output)
+		case TraceRemoveInfix:
+			fmt.Fprintf(&sb, "remove %s: %q => %q", step.Rule, step.Input, step.Output)
+		case TraceRecoding:
+			fmt.Fprintf(&sb, "recoding %q: %q => %q, %s", step.Rule, step.Input, step.Output, foundString(step.Found))
+		case TraceRestoreSuffix:
+			fmt.Fprintf(&sb, "restore suffix %q: %q => %q", step.Rule, step.Input, step.Output)
+		case TraceReduplication:
+			fmt.Fprintf(&sb, "reduplication: %q => %q, %q", step.Input, step.Output, step.Rule)
+		default:
+			fmt.Fprintf(&sb, "%s %q => %q", step.Action, step.Input, step.Output)
+		}
+
+		sb.WriteString("\n")
+	}
+
+	fmt.Fprintf(&sb, "root %q: %s\n", trace.Root, foundString(trace.Found))
+	return sb.String()
+}
+
+func (trace *Trace) add(step TraceStep) {
+	if trace == nil {
+		return
+	}
+
+	trace.Steps = append(trace.Steps, step)
+}
+
+func (trace *Trace) addRemoval(rule string, affix string, result string) {
+	if trace == nil {
+		return
+	}
+
+	trace.add(TraceStep{Action: TraceRemoveSuffix, Rule: rule, Input: result + affix, Output: result})
+}
+
+func (trace *Trace) addPrefixRemoval(rule prefixRule, word string, result string) {
+	if trace == nil {
+		return
+	}
+
+	action := TraceRemovePrefix
+	if rule.family == "infix" {
+		action = TraceRemoveInfix
+	}
+
+	trace.add(TraceStep{Action: action, Rule: rule.String(), Input: word, Output: result})
+}
+
+// contains checks word against dictionary, and records it if analysis is traced
+func (stemmer Stemmer) contains(word string, analysis *Analysis) bool {
+	found := stemmer.lookup(word)
+	analysis.trace.add(TraceStep{Action: TraceLookup, Input: word, Found: found})
+	return found
+}
+
+// prefixRule is the prefix pattern that applied by removePrefix
+type prefixRule struct {
+	family  string
+	pattern int
+}
+
+func (rule prefixRule) String() string {
+	if rule.pattern == 0 {
+		return rule.family
+	}
+
+	return fmt.Sprintf("%s-%d", rule.family, rule.pattern)
+}
+
+func foundString(found bool) string {
+	if found {
+		return "found"
+	}
+
+	return "not found"
+}
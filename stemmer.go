@@ -2,25 +2,91 @@ package sastrawi
 
 import (
 	"strings"
+	"unicode/utf8"
 )
 
 // Stemmer is object for stemming word
 type Stemmer struct {
-	dictionary Dictionary
+	dictionary        RootLookup
+	keepReduplication bool
 }
 
 // NewStemmer returns new Stemmer using dict as its dictionary
-func NewStemmer(dict Dictionary) Stemmer {
-	return Stemmer{dict}
+func NewStemmer(dict RootLookup) Stemmer {
+	return Stemmer{
+		dictionary:        dict,
+		keepReduplication: true,
+	}
 }
 
 // ChangeDictionary changes dictionary that used in Stemmer
-func (stemmer *Stemmer) ChangeDictionary(dict Dictionary) {
+func (stemmer *Stemmer) ChangeDictionary(dict RootLookup) {
 	stemmer.dictionary = dict
 }
 
+// KeepReduplication sets whether reduplicated word that exists in dictionary,
+// e.g. "kupu-kupu", is kept as it is (the default) or reduced to its root "kupu"
+func (stemmer *Stemmer) KeepReduplication(keep bool) {
+	stemmer.keepReduplication = keep
+}
+
+// lookup checks if word exists in dictionary. Stemmer without dictionary, e.g.
+// the zero value of Stemmer, is treated as having empty dictionary.
+func (stemmer Stemmer) lookup(word string) bool {
+	return stemmer.dictionary != nil && stemmer.dictionary.Contains(word)
+}
+
+// Analysis is the result of decomposing a word into its root and affixes
+type Analysis struct {
+	// Word is the analyzed word in lower case
+	Word string
+
+	// Root is the root form of Word
+	Root string
+
+	// Prefixes are the removed prefixes, in the order they were stripped
+	Prefixes []string
+
+	// Suffix is the removed derivational suffix, e.g. "kan" or "an"
+	Suffix string
+
+	// Possessive is the removed possessive pronoun, i.e. "ku", "mu" or "nya"
+	Possessive string
+
+	// Particle is the removed particle, i.e. "lah", "kah", "tah" or "pun"
+	Particle string
+
+	// Recoding is the character that restored at the start of root after
+	// the last prefix removed, e.g. "p" in mem-(p)ukul
+	Recoding string
+
+	// Infix is the removed infix, i.e. "el", "em", "er" or "in", that inserted
+	// after the first consonant of Root, e.g. "em" in l-em-igas
+	Infix string
+
+	// Found is true if Root exists in dictionary. If false, Root is
+	// the original word that returned because no root found.
+	Found bool
+
+	// Reduplicated is true if Word is reduplicated word, e.g. "anak-anak".
+	// In this case, the affixes are the ones that removed from the half
+	// of Word that has most affixes.
+	Reduplicated bool
+
+	trace *Trace
+}
+
 // Stem reduces inflected or derived word to its root form
 func (stemmer Stemmer) Stem(word string) string {
+	return stemmer.Analyze(word).Root
+}
+
+// Analyze reduces word to its root form, and reports the affixes that removed from it
+func (stemmer Stemmer) Analyze(word string) Analysis {
+	return stemmer.analyze(word, nil)
+}
+
+func (stemmer Stemmer) analyze(word string, trace *Trace) Analysis {
 	word = strings.ToLower(word)
 
 	var (
@@ -29,64 +95,87 @@ func (stemmer Stemmer) Stem(word string) string {
 		particle     string
 		possesive    string
 		suffix       string
+		analysis     = Analysis{Word: word, Root: word, trace: trace}
 	)
 
 	if len(word) < 3 {
-		return word
+		analysis.Found = stemmer.contains(word, &analysis)
+		return analysis
 	}
 
-	if stemmer.dictionary.Contains(word) {
-		return word
+	first, second, isReduplication := splitReduplication(word)
+	if (!isReduplication || stemmer.keepReduplication) && stemmer.contains(word, &analysis) {
+		return analysis.withRoot(word)
+	}
+
+	if isReduplication {
+		return stemmer.analyzeReduplication(word, first, second, trace)
 	}
 
 	// Check if prefix must be removed first
 	if rxPrefixFirst.MatchString(word) {
+		trace.add(TraceStep{Action: TraceBranch, Rule: "prefix-first", Input: word})
+
 		// Remove prefix
-		rootFound, word = stemmer.removePrefixes(word)
+		rootFound, word = stemmer.removePrefixes(word, &analysis)
 		if rootFound {
-			return word
+			return analysis.withRoot(word)
 		}
 
 		// Remove particle
 		particle, word = stemmer.removeParticle(word)
-		if stemmer.dictionary.Contains(word) {
-			return word
+		trace.addRemoval("particle", particle, word)
+		analysis.Particle = trimHyphen(particle)
+		if stemmer.contains(word, &analysis) {
+			return analysis.withRoot(word)
 		}
 
 		// Remove possesive
 		possesive, word = stemmer.removePossesive(word)
-		if stemmer.dictionary.Contains(word) {
-			return word
+		trace.addRemoval("possessive", possesive, word)
+		analysis.Possessive = trimHyphen(possesive)
+		if stemmer.contains(word, &analysis) {
+			return analysis.withRoot(word)
 		}
 
 		// Remove suffix
 		suffix, word = stemmer.removeSuffix(word)
-		if stemmer.dictionary.Contains(word) {
-			return word
+		trace.addRemoval("suffix", suffix, word)
+		analysis.Suffix = trimHyphen(suffix)
+		if stemmer.contains(word, &analysis) {
+			return analysis.withRoot(word)
 		}
 	} else {
+		trace.add(TraceStep{Action: TraceBranch, Rule: "suffix-first", Input: word})
+
 		// Remove particle
 		particle, word = stemmer.removeParticle(word)
-		if stemmer.dictionary.Contains(word) {
-			return word
+		trace.addRemoval("particle", particle, word)
+		analysis.Particle = trimHyphen(particle)
+		if stemmer.contains(word, &analysis) {
+			return analysis.withRoot(word)
 		}
 
 		// Remove possesive
 		possesive, word = stemmer.removePossesive(word)
-		if stemmer.dictionary.Contains(word) {
-			return word
+		trace.addRemoval("possessive", possesive, word)
+		analysis.Possessive = trimHyphen(possesive)
+		if stemmer.contains(word, &analysis) {
+			return analysis.withRoot(word)
 		}
 
 		// Remove suffix
 		suffix, word = stemmer.removeSuffix(word)
-		if stemmer.dictionary.Contains(word) {
-			return word
+		trace.addRemoval("suffix", suffix, word)
+		analysis.Suffix = trimHyphen(suffix)
+		if stemmer.contains(word, &analysis) {
+			return analysis.withRoot(word)
 		}
 
 		// Remove prefix
-		rootFound, word = stemmer.removePrefixes(word)
+		rootFound, word = stemmer.removePrefixes(word, &analysis)
 		if rootFound {
-			return word
+			return analysis.withRoot(word)
 		}
 	}
 
@@ -96,13 +185,73 @@ func (stemmer Stemmer) Stem(word string) string {
 		removedSuffixes = []string{"", "k", "an", possesive, particle}
 	}
 
-	rootFound, word = stemmer.loopPengembalianAkhiran(originalWord, removedSuffixes)
+	rootFound, word, nRestored := stemmer.loopPengembalianAkhiran(originalWord, removedSuffixes, &analysis)
 	if rootFound {
-		return word
+		// Suffixes that restored are no longer removed. The last two
+		// of removed suffixes are always possesive and particle.
+		suffixParts := removedSuffixes[1 : len(removedSuffixes)-2]
+		if nRestored < len(suffixParts) {
+			analysis.Suffix = trimHyphen(strings.Join(suffixParts[nRestored:], ""))
+		} else {
+			analysis.Suffix = ""
+		}
+
+		if nRestored > len(suffixParts) {
+			analysis.Possessive = ""
+		}
+
+		if nRestored > len(suffixParts)+1 {
+			analysis.Particle = ""
+		}
+
+		return analysis.withRoot(word)
 	}
 
 	// When EVERYTHING failed, return original word
-	return originalWord
+	return Analysis{Word: originalWord, Root: originalWord}
+}
+
+// withRoot returns copy of analysis with root that found in dictionary
+func (analysis Analysis) withRoot(root string) Analysis {
+	analysis.Root = root
+	analysis.Found = true
+	return analysis
+}
+
+// addPrefix records the prefix that removed from word, which turns it into result
+func (analysis *Analysis) addPrefix(word, result string) {
+	// Some patterns restore the root's first character by themselves
+	// (e.g. meny-(s)uara), so the removed prefix is everything before the
+	// part that shared by word and result.
+	nShared := 0
+	for nShared < len(result) && word[len(word)-1-nShared] == result[len(result)-1-nShared] {
+		nShared++
+	}
+
+	prefix := word[:len(word)-nShared]
+	if prefix == "" {
+		return
+	}
+
+	analysis.Prefixes = append(analysis.Prefixes, prefix)
+	analysis.Recoding = result[:len(result)-nShared]
+}
+
+// addInfix replaces the prefix that recorded for word with infix. Infix rule removes
+// the start of word until the infix, e.g. "lem" from lemigas, then the consonant is
+// restored as recoding character, so the infix is what between them.
+func (analysis *Analysis) addInfix(word, result, recoding string) {
+	analysis.Prefixes = analysis.Prefixes[:len(analysis.Prefixes)-1]
+	if len(analysis.Prefixes) == 0 {
+		analysis.Prefixes = nil
+	}
+
+	analysis.Recoding = ""
+	analysis.Infix = word[len(recoding) : len(word)-len(result)]
+}
+
+func trimHyphen(affix string) string {
+	return strings.TrimLeft(affix, "-")
 }
 
 func (stemmer Stemmer) removeParticle(word string) (string, string) {
@@ -123,7 +272,7 @@ func (stemmer Stemmer) removeSuffix(word string) (string, string) {
 	return suffix, result
 }
 
-func (stemmer Stemmer) loopPengembalianAkhiran(originalWord string, suffixes []string) (bool, string) {
+func (stemmer Stemmer) loopPengembalianAkhiran(originalWord string, suffixes []string, analysis *Analysis) (bool, string, int) {
 	lenSuffixes := 0
 	for _, suffix := range suffixes {
 		lenSuffixes += len(suffix)
@@ -131,33 +280,43 @@ func (stemmer Stemmer) loopPengembalianAkhiran(originalWord string, suffixes []s
 	wordWithoutSuffix := originalWord[:len(originalWord)-lenSuffixes]
 
 	for i := range suffixes {
+		// Prefixes that recorded by the previous attempt are no longer valid
+		analysis.Prefixes = nil
+		analysis.Recoding = ""
+		analysis.Infix = ""
+
 		suffixCombination := ""
 		for j := 0; j <= i; j++ {
 			suffixCombination += suffixes[j]
 		}
 
 		word := wordWithoutSuffix + suffixCombination
-		if stemmer.dictionary.Contains(word) {
-			return true, word
+		analysis.trace.add(TraceStep{Action: TraceRestoreSuffix, Rule: suffixCombination, Input: originalWord, Output: word})
+		if stemmer.contains(word, analysis) {
+			return true, word, i
 		}
 
-		rootFound, word := stemmer.removePrefixes(word)
+		rootFound, word := stemmer.removePrefixes(word, analysis)
 		if rootFound {
-			return true, word
+			return true, word, i
 		}
 	}
 
-	return false, originalWord
+	return false, originalWord, 0
 }
 
-func (stemmer Stemmer) removePrefixes(word string) (bool, string) {
+func (stemmer Stemmer) removePrefixes(word string, analysis *Analysis) (bool, string) {
 	originalWord := word
+	nOriginalPrefixes := len(analysis.Prefixes)
 	currentPrefix := ""
 	removedPrefix := ""
 	recodingChar := []string{}
+	rule := prefixRule{}
 
 	for i := 0; i < 3; i++ {
 		if len(word) < 3 {
+			analysis.Prefixes = analysis.Prefixes[:nOriginalPrefixes]
+			analysis.Recoding = ""
 			return false, originalWord
 		}
 
@@ -166,13 +325,23 @@ func (stemmer Stemmer) removePrefixes(word string) (bool, string) {
 			break
 		}
 
-		removedPrefix, word, recodingChar = stemmer.removePrefix(word)
-		if stemmer.dictionary.Contains(word) {
+		previousWord := word
+		removedPrefix, word, recodingChar, rule = stemmer.removePrefix(word)
+		analysis.addPrefix(previousWord, word)
+		analysis.trace.addPrefixRemoval(rule, previousWord, word)
+		if stemmer.contains(word, analysis) {
 			return true, word
 		}
 
 		for _, char := range recodingChar {
-			if stemmer.dictionary.Contains(char + word) {
+			found := stemmer.lookup(char + word)
+			analysis.trace.add(TraceStep{Action: TraceRecoding, Rule: char, Input: word, Output: char + word, Found: found})
+			if found {
+				analysis.Recoding = char
+				if rule.family == "infix" {
+					analysis.addInfix(previousWord, word, char)
+				}
+
 				return true, char + word
 			}
 		}
@@ -181,321 +350,421 @@ func (stemmer Stemmer) removePrefixes(word string) (bool, string) {
 	return false, word
 }
 
-func (stemmer Stemmer) removePrefix(word string) (prefix string, result string, recoding []string) {
+func (stemmer Stemmer) removePrefix(word string) (prefix string, result string, recoding []string, rule prefixRule) {
 	if strings.HasPrefix(word, "kau") {
-		return "kau", word[3:], nil
+		return "kau", word[3:], nil, prefixRule{family: "kau"}
 	}
 
 	prefix = word[:2]
+	rule.family = prefix
 	switch prefix {
 	case "di", "ke", "se", "ku":
 		result = word[2:]
 	case "me":
-		result, recoding = stemmer.removePrefixMe(word)
+		result, recoding, rule.pattern = stemmer.removePrefixMe(word)
 	case "pe":
-		result, recoding = stemmer.removePrefixPe(word)
+		result, recoding, rule.pattern = stemmer.removePrefixPe(word)
 	case "be":
-		result, recoding = stemmer.removePrefixBe(word)
+		result, recoding, rule.pattern = stemmer.removePrefixBe(word)
 	case "te":
-		result, recoding = stemmer.removePrefixTe(word)
+		result, recoding, rule.pattern = stemmer.removePrefixTe(word)
 	default:
-		result, recoding = stemmer.removeInfix(word)
+		rule.family = "infix"
+		result, recoding, rule.pattern = stemmer.removeInfix(word)
 	}
 
-	return prefix, result, recoding
+	return prefix, result, recoding, rule
 }
 
-func (stemmer Stemmer) removePrefixMe(word string) (string, []string) {
-	// Pattern 1
-	// me{l|r|w|y}V => me-{l|r|w|y}V
-	matches := rxPrefixMe1.FindStringSubmatch(word)
-	if len(matches) != 0 {
-		return matches[1], nil
+func (stemmer Stemmer) removePrefixMe(word string) (string, []string, int) {
+	if !strings.HasPrefix(word, "me") {
+		return word, nil, 0
 	}
 
-	// Pattern 2
-	// mem{b|f|v} => mem-{b|f|v}
-	matches = rxPrefixMe2.FindStringSubmatch(word)
-	if len(matches) != 0 {
-		return matches[1], nil
+	// Pattern 1
+	// me{l|r|w|y}V => me-{l|r|w|y}V
+	if isAnyOf(word, 2, "lrwy") && isVowel(word, 3) && isTail(word, 4) {
+		return word[2:], nil, 1
 	}
 
-	// Pattern 3
-	// mempe => mem-pe
-	matches = rxPrefixMe3.FindStringSubmatch(word)
-	if len(matches) != 0 {
-		return matches[1], nil
-	}
+	if strings.HasPrefix(word, "mem") {
+		// Pattern 2
+		// mem{b|f|v} => mem-{b|f|v}
+		if isAnyOf(word, 3, "bfv") && isTail(word, 4) {
+			return word[3:], nil, 2
+		}
 
-	// Pattern 4
-	// mem{rV|V} => mem-{rV|V} OR me-p{rV|V}
-	matches = rxPrefixMe4.FindStringSubmatch(word)
-	if len(matches) != 0 {
-		return matches[1], []string{"m", "p"}
-	}
+		// Pattern 3
+		// mempe => mem-pe
+		if strings.HasPrefix(word, "mempe") && isTail(word, 5) {
+			return word[3:], nil, 3
+		}
 
-	// Pattern 5
-	// men{c|d|j|s|t|z} => men-{c|d|j|s|t|z}
-	matches = rxPrefixMe5.FindStringSubmatch(word)
-	if len(matches) != 0 {
-		return matches[1], nil
+		// Pattern 4
+		// mem{rV|V} => mem-{rV|V} OR me-p{rV|V}
+		if i := skipByte(word, 3, 'r'); isVowel(word, i) && isTail(word, i+1) {
+			return word[3:], recodingMP, 4
+		}
 	}
 
-	// Pattern 6
-	// menV => nV OR tV
-	matches = rxPrefixMe6.FindStringSubmatch(word)
-	if len(matches) != 0 {
-		return matches[1], []string{"n", "t"}
-	}
+	if strings.HasPrefix(word, "men") {
+		// Pattern 5
+		// men{c|d|j|s|t|z} => men-{c|d|j|s|t|z}
+		if isAnyOf(word, 3, "cdjstz") && isTail(word, 4) {
+			return word[3:], nil, 5
+		}
 
-	// Pattern 7
-	// meng{g|h|q|k} => meng-{g|h|q|k}
-	matches = rxPrefixMe7.FindStringSubmatch(word)
-	if len(matches) != 0 {
-		return matches[1], nil
+		// Pattern 6
+		// menV => nV OR tV
+		if isVowel(word, 3) && isTail(word, 4) {
+			return word[3:], recodingNT, 6
+		}
 	}
 
-	// Pattern 8
-	// mengV => meng-V OR meng-kV OR me-ngV OR mengV- where V = 'e'
-	matches = rxPrefixMe8.FindStringSubmatch(word)
-	if len(matches) != 0 {
-		if matches[2] == "e" {
-			return matches[3], nil
+	if strings.HasPrefix(word, "meng") {
+		// Pattern 7
+		// meng{g|h|q|k} => meng-{g|h|q|k}
+		if isAnyOf(word, 4, "ghqk") && isTail(word, 5) {
+			return word[4:], nil, 7
 		}
 
-		return matches[1], []string{"ng", "k"}
+		// Pattern 8
+		// mengV => meng-V OR meng-kV OR me-ngV OR mengV- where V = 'e'
+		if isVowel(word, 4) && isTail(word, 5) {
+			if word[4] == 'e' {
+				return word[5:], nil, 8
+			}
+
+			return word[4:], recodingNgK, 8
+		}
 	}
 
 	// Pattern 9
 	// menyV => meny-sV OR me-nyV to stem menyala
-	matches = rxPrefixMe9.FindStringSubmatch(word)
-	if len(matches) != 0 {
-		if matches[2] == "a" {
-			return "ny" + matches[1], nil
+	if strings.HasPrefix(word, "meny") && isVowel(word, 4) && isTail(word, 5) {
+		if word[4] == 'a' {
+			return "ny" + word[4:], nil, 9
 		}
 
-		return "s" + matches[1], nil
+		return "s" + word[4:], nil, 9
 	}
 
 	// Pattern 10
 	// mempV => mem-pA where A != 'e'
-	matches = rxPrefixMe10.FindStringSubmatch(word)
-	if len(matches) != 0 {
-		return matches[1], nil
+	if strings.HasPrefix(word, "memp") {
+		if n := notAnyOf(word, 4, "e"); n > 0 && isTail(word, 4+n) {
+			return word[3:], nil, 10
+		}
 	}
 
-	return word, nil
+	return word, nil, 0
 }
 
-func (stemmer Stemmer) removePrefixPe(word string) (string, []string) {
-	// Pattern 1
-	// pe{w|y}V => pe-{w|y}V
-	matches := rxPrefixPe1.FindStringSubmatch(word)
-	if len(matches) != 0 {
-		return matches[1], nil
+func (stemmer Stemmer) removePrefixPe(word string) (string, []string, int) {
+	if !strings.HasPrefix(word, "pe") {
+		return word, nil, 0
 	}
 
-	// Pattern 2
-	// perV => per-V OR pe-rV
-	matches = rxPrefixPe2.FindStringSubmatch(word)
-	if len(matches) != 0 {
-		return matches[1], []string{"r"}
+	// Pattern 1
+	// pe{w|y}V => pe-{w|y}V
+	if isAnyOf(word, 2, "wy") && isVowel(word, 3) && isTail(word, 4) {
+		return word[2:], nil, 1
 	}
 
-	// Pattern 3
-	// perCAP => per-CAP where C != 'r' and P != 'er'
-	matches = rxPrefixPe3.FindStringSubmatch(word)
-	if len(matches) != 0 {
-		return matches[1], nil
-	}
+	if strings.HasPrefix(word, "per") {
+		// Pattern 2
+		// perV => per-V OR pe-rV
+		if isVowel(word, 3) && isTail(word, 4) {
+			return word[3:], recodingR, 2
+		}
 
-	// Pattern 4
-	// perCAerV => per-CAerV where C != 'r'
-	matches = rxPrefixPe4.FindStringSubmatch(word)
-	if len(matches) != 0 {
-		return matches[1], nil
-	}
+		if n := notAnyOf(word, 3, "aiueor"); n > 0 && isLetter(word, 3+n) {
+			// Pattern 3
+			// perCAP => per-CAP where C != 'r' and P != 'er'
+			i := 3 + n + 1
+			if n := notAnyOf(word, i, "e"); n > 0 && isTail(word, i+n) {
+				return word[3:], nil, 3
+			}
 
-	// Pattern 5
-	// pem{b|f|v} => pem-{b|f|v}
-	matches = rxPrefixPe5.FindStringSubmatch(word)
-	if len(matches) != 0 {
-		return matches[1], nil
+			// Pattern 4
+			// perCAerV => per-CAerV where C != 'r'
+			if strings.HasPrefix(word[i:], "er") && isVowel(word, i+2) && isTail(word, i+3) {
+				return word[3:], nil, 4
+			}
+		}
 	}
 
-	// Pattern 6
-	// pem{rV|V} => pe-m{rV|V} OR pe-p{rV|V}
-	matches = rxPrefixPe6.FindStringSubmatch(word)
-	if len(matches) != 0 {
-		return matches[1], []string{"m", "p"}
-	}
+	if strings.HasPrefix(word, "pem") {
+		// Pattern 5
+		// pem{b|f|v} => pem-{b|f|v}
+		if isAnyOf(word, 3, "bfv") && isTail(word, 4) {
+			return word[3:], nil, 5
+		}
 
-	// Pattern 7
-	// pen{c|d|j|s|t|z} => pen-{c|d|j|s|t|z}
-	matches = rxPrefixPe7.FindStringSubmatch(word)
-	if len(matches) != 0 {
-		return matches[1], nil
+		// Pattern 6
+		// pem{rV|V} => pe-m{rV|V} OR pe-p{rV|V}
+		if i := skipByte(word, 3, 'r'); isVowel(word, i) && isTail(word, i+1) {
+			return word[3:], recodingMP, 6
+		}
 	}
 
-	// Pattern 8
-	// penV => pe-nV OR pe-tV
-	matches = rxPrefixPe8.FindStringSubmatch(word)
-	if len(matches) != 0 {
-		return matches[1], []string{"n", "t"}
-	}
+	if strings.HasPrefix(word, "pen") {
+		// Pattern 7
+		// pen{c|d|j|s|t|z} => pen-{c|d|j|s|t|z}
+		if isAnyOf(word, 3, "cdjstz") && isTail(word, 4) {
+			return word[3:], nil, 7
+		}
 
-	// Pattern 9
-	// pengC => peng-C
-	matches = rxPrefixPe9.FindStringSubmatch(word)
-	if len(matches) != 0 {
-		return matches[1], nil
+		// Pattern 8
+		// penV => pe-nV OR pe-tV
+		if isVowel(word, 3) && isTail(word, 4) {
+			return word[3:], recodingNT, 8
+		}
 	}
 
-	// Pattern 10
-	// pengV => peng-V OR peng-kV OR pengV- where V = 'e'
-	matches = rxPrefixPe10.FindStringSubmatch(word)
-	if len(matches) != 0 {
-		if matches[2] == "e" {
-			return matches[3], nil
+	if strings.HasPrefix(word, "peng") {
+		// Pattern 9
+		// pengC => peng-C
+		if n := notAnyOf(word, 4, "aiueo"); n > 0 && isTail(word, 4+n) {
+			return word[4:], nil, 9
 		}
 
-		return matches[1], []string{"k"}
+		// Pattern 10
+		// pengV => peng-V OR peng-kV OR pengV- where V = 'e'
+		if isVowel(word, 4) && isTail(word, 5) {
+			if word[4] == 'e' {
+				return word[5:], nil, 10
+			}
+
+			return word[4:], recodingK, 10
+		}
 	}
 
 	// Pattern 11
 	// penyV => peny-sV OR pe-nyV
-	matches = rxPrefixPe11.FindStringSubmatch(word)
-	if len(matches) != 0 {
-		return matches[1], []string{"s", "ny"}
+	if strings.HasPrefix(word, "peny") && isVowel(word, 4) && isTail(word, 5) {
+		return word[4:], recodingSNy, 11
 	}
 
 	// Pattern 12
 	// pelV => pe-lV OR pel-V for pelajar
-	matches = rxPrefixPe12.FindStringSubmatch(word)
-	if len(matches) != 0 {
+	if strings.HasPrefix(word, "pel") && isVowel(word, 3) && isTail(word, 4) {
 		if word == "pelajar" {
-			return "ajar", nil
+			return "ajar", nil, 12
 		}
 
-		return matches[1], nil
+		return word[2:], nil, 12
 	}
 
-	// Pattern 13
-	// peCerV => peC-erV where C != {r|w|y|l|m|n}
-	matches = rxPrefixPe13.FindStringSubmatch(word)
-	if len(matches) != 0 {
-		return matches[1], nil
-	}
+	if n := notAnyOf(word, 2, "aiueorwylmn"); n > 0 {
+		i := 2 + n
+		isEr := strings.HasPrefix(word[i:], "er")
 
-	// Pattern 14
-	// peCP => pe-CP where C != {r|w|y|l|m|n} and P != 'er'
-	matches = rxPrefixPe14.FindStringSubmatch(word)
-	if len(matches) != 0 {
-		return matches[1], nil
-	}
+		// Pattern 13
+		// peCerV => peC-erV where C != {r|w|y|l|m|n}
+		if isEr && isVowel(word, i+2) && isTail(word, i+3) {
+			return word[i:], nil, 13
+		}
+
+		// Pattern 14
+		// peCP => pe-CP where C != {r|w|y|l|m|n} and P != 'er'
+		if n := notAnyOf(word, i, "e"); n > 0 && isTail(word, i+n) {
+			return word[2:], nil, 14
+		}
 
-	// Pattern 15
-	// peC1erC2 => pe-C1erC2 where C1 != {r|w|y|l|m|n}
-	matches = rxPrefixPe15.FindStringSubmatch(word)
-	if len(matches) != 0 {
-		return matches[1], nil
+		// Pattern 15
+		// peC1erC2 => pe-C1erC2 where C1 != {r|w|y|l|m|n}
+		if isEr {
+			if n := notAnyOf(word, i+2, "aiueo"); n > 0 && isTail(word, i+2+n) {
+				return word[2:], nil, 15
+			}
+		}
 	}
 
-	return word, nil
+	return word, nil, 0
 }
 
-func (stemmer Stemmer) removePrefixBe(word string) (string, []string) {
-	// Pattern 1
-	// berV => ber-V OR be-rV
-	matches := rxPrefixBe1.FindStringSubmatch(word)
-	if len(matches) != 0 {
-		return matches[1], []string{"r"}
+func (stemmer Stemmer) removePrefixBe(word string) (string, []string, int) {
+	if !strings.HasPrefix(word, "be") {
+		return word, nil, 0
 	}
 
-	// Pattern 2
-	// berCAP => ber-CAP where C != 'r' and P != 'er'
-	matches = rxPrefixBe2.FindStringSubmatch(word)
-	if len(matches) != 0 {
-		return matches[1], nil
-	}
+	if strings.HasPrefix(word, "ber") {
+		// Pattern 1
+		// berV => ber-V OR be-rV
+		if isVowel(word, 3) && isTail(word, 4) {
+			return word[3:], recodingR, 1
+		}
 
-	// Pattern 3
-	// berCAerV => ber-CAerV where C != 'r'
-	matches = rxPrefixBe3.FindStringSubmatch(word)
-	if len(matches) != 0 {
-		return matches[1], nil
+		if n := notAnyOf(word, 3, "aiueor"); n > 0 && isLetter(word, 3+n) {
+			// Pattern 2
+			// berCAP => ber-CAP where C != 'r' and P != 'er'
+			i := 3 + n + 1
+			if n := notAnyOf(word, i, "e"); n > 0 && isTail(word, i+n) {
+				return word[3:], nil, 2
+			}
+
+			// Pattern 3
+			// berCAerV => ber-CAerV where C != 'r'
+			if strings.HasPrefix(word[i:], "er") && isVowel(word, i+2) && isTail(word, i+3) {
+				return word[3:], nil, 3
+			}
+		}
 	}
 
 	// Pattern 4
 	// belajar => bel-ajar
-	matches = rxPrefixBe4.FindStringSubmatch(word)
-	if len(matches) != 0 {
-		return matches[1], nil
+	if word == "belajar" {
+		return "ajar", nil, 4
 	}
 
 	// Pattern 5
 	// beC1erC2 => be-C1erC2 where C1 != {'r'|'l'}
-	matches = rxPrefixBe5.FindStringSubmatch(word)
-	if len(matches) != 0 {
-		return matches[1], nil
+	if n := notAnyOf(word, 2, "aiueorl"); n > 0 && strings.HasPrefix(word[2+n:], "er") {
+		i := 2 + n + 2
+		if n := notAnyOf(word, i, "aiueo"); n > 0 && isTail(word, i+n) {
+			return word[2:], nil, 5
+		}
 	}
 
-	return word, nil
+	return word, nil, 0
 }
 
-func (stemmer Stemmer) removePrefixTe(word string) (string, []string) {
-	// Pattern 1
-	// terV => ter-V OR te-rV
-	matches := rxPrefixTe1.FindStringSubmatch(word)
-	if len(matches) != 0 {
-		return matches[1], []string{"r"}
+func (stemmer Stemmer) removePrefixTe(word string) (string, []string, int) {
+	if !strings.HasPrefix(word, "te") {
+		return word, nil, 0
 	}
 
-	// Pattern 2
-	// terCerV => ter-CerV where C != 'r'
-	matches = rxPrefixTe2.FindStringSubmatch(word)
-	if len(matches) != 0 {
-		return matches[1], nil
-	}
+	if strings.HasPrefix(word, "ter") {
+		// Pattern 1
+		// terV => ter-V OR te-rV
+		if isVowel(word, 3) && isTail(word, 4) {
+			return word[3:], recodingR, 1
+		}
+
+		if n := notAnyOf(word, 3, "aiueor"); n > 0 {
+			i := 3 + n
+			isEr := strings.HasPrefix(word[i:], "er")
+
+			// Pattern 2
+			// terCerV => ter-CerV where C != 'r'
+			if isEr && isVowel(word, i+2) && isTail(word, i+3) {
+				return word[3:], nil, 2
+			}
 
-	// Pattern 3
-	// terCP => ter-CP where C != 'r' and P != 'er'
-	matches = rxPrefixTe3.FindStringSubmatch(word)
-	if len(matches) != 0 {
-		return matches[1], nil
+			// Pattern 3
+			// terCP => ter-CP where C != 'r' and P != 'er'
+			if n := notAnyOf(word, i, "e"); n > 0 && isTail(word, i+n) {
+				return word[3:], nil, 3
+			}
+		}
 	}
 
 	// Pattern 4
 	// teC1erC2 => te-C1erC2 where C1 != 'r'
-	matches = rxPrefixTe4.FindStringSubmatch(word)
-	if len(matches) != 0 {
-		return matches[1], nil
+	if n := notAnyOf(word, 2, "aiueor"); n > 0 && strings.HasPrefix(word[2+n:], "er") {
+		i := 2 + n + 2
+		if n := notAnyOf(word, i, "aiueo"); n > 0 && isTail(word, i+n) {
+			return word[2:], nil, 4
+		}
 	}
 
 	// Pattern 5
 	// terC1erC2 => ter-C1erC2 where C1 != 'r'
-	matches = rxPrefixTe5.FindStringSubmatch(word)
-	if len(matches) != 0 {
-		return matches[1], nil
+	if strings.HasPrefix(word, "ter") {
+		if n := notAnyOf(word, 3, "aiueor"); n > 0 && strings.HasPrefix(word[3+n:], "er") {
+			i := 3 + n + 2
+			if n := notAnyOf(word, i, "aiueo"); n > 0 && isTail(word, i+n) {
+				return word[3:], nil, 5
+			}
+		}
 	}
 
-	return word, nil
+	return word, nil, 0
 }
 
-func (stemmer Stemmer) removeInfix(word string) (string, []string) {
+func (stemmer Stemmer) removeInfix(word string) (string, []string, int) {
+	n := notAnyOf(word, 0, "aiueo")
+	if n == 0 {
+		return word, nil, 0
+	}
+
 	// Pattern 1
 	// CerV => CerV OR CV
-	matches := rxInfix1.FindStringSubmatch(word)
-	if len(matches) != 0 {
-		return matches[3], []string{matches[1], matches[2]}
+	if isByte(word, n, 'e') && isAnyOf(word, n+1, "rlm") && isVowel(word, n+2) && isTail(word, n+3) {
+		return word[n+2:], []string{word[:n+2], word[:n]}, 1
 	}
 
 	// Pattern 2
 	// CinV => CinV OR CV
-	matches = rxInfix2.FindStringSubmatch(word)
-	if len(matches) != 0 {
-		return matches[3], []string{matches[1], matches[2]}
+	if isByte(word, n, 'i') && isByte(word, n+1, 'n') && isVowel(word, n+2) && isTail(word, n+3) {
+		return word[n+2:], []string{word[:n+2], word[:n]}, 2
+	}
+
+	return word, nil, 0
+}
+
+// Recoding characters that returned by prefix rules. They are shared
+// to avoid allocating a new slice every time a rule matched.
+var (
+	recodingMP  = []string{"m", "p"}
+	recodingNT  = []string{"n", "t"}
+	recodingNgK = []string{"ng", "k"}
+	recodingK   = []string{"k"}
+	recodingR   = []string{"r"}
+	recodingSNy = []string{"s", "ny"}
+)
+
+// isByte checks if word[i] is c
+func isByte(word string, i int, c byte) bool {
+	return i < len(word) && word[i] == c
+}
+
+// isAnyOf checks if word[i] is one of chars
+func isAnyOf(word string, i int, chars string) bool {
+	return i < len(word) && strings.IndexByte(chars, word[i]) >= 0
+}
+
+// isVowel checks if word[i] is one of a, i, u, e and o
+func isVowel(word string, i int) bool {
+	return isAnyOf(word, i, "aiueo")
+}
+
+// isLetter checks if word[i] is within a-z
+func isLetter(word string, i int) bool {
+	return i < len(word) && word[i] >= 'a' && word[i] <= 'z'
+}
+
+// skipByte returns i+1 if word[i] is c, or i otherwise
+func skipByte(word string, i int, c byte) int {
+	if isByte(word, i, c) {
+		return i + 1
+	}
+
+	return i
+}
+
+// notAnyOf returns the byte length of character that starts at word[i]
+// if it's not one of chars, or 0 otherwise. Like [^chars] in regex,
+// the character may be any UTF-8 character including new line.
+func notAnyOf(word string, i int, chars string) int {
+	if i >= len(word) {
+		return 0
 	}
 
-	return word, nil
+	if c := word[i]; c < utf8.RuneSelf {
+		if strings.IndexByte(chars, c) >= 0 {
+			return 0
+		}
+
+		return 1
+	}
+
+	_, size := utf8.DecodeRuneInString(word[i:])
+	return size
+}
+
+// isTail checks if word[i:] can be matched by .* in regex, i.e. it doesn't contain new line
+func isTail(word string, i int) bool {
+	return i <= len(word) && strings.IndexByte(word[i:], '\n') < 0
 }
@@ -1,6 +1,10 @@
 package sastrawi
 
-import "testing"
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
 
 type testItem struct {
 	value    string
@@ -166,6 +170,16 @@ func TestStemmer(t *testing.T) {
 		{value: "kuasa-Mu", expected: "kuasa"},
 		{value: "nikmat-Ku", expected: "nikmat"},
 		{value: "allah-lah", expected: "allah"},
+		{value: "malaikat-malaikat-nya", expected: "malaikat"},
+		{value: "meniru-nirukan", expected: "tiru"},
+		{value: "sepak-menyepak", expected: "sepak"},
+		{value: "anak-anak", expected: "anak"},
+		{value: "buku-bukunya", expected: "buku"},
+		{value: "berlari-lari", expected: "lari"},
+		{value: "sayur-mayur", expected: "sayur"},
+		{value: "bolak-balik", expected: "balik"},
+		{value: "kupu-kupu", expected: "kupu-kupu"},
+		{value: "marwan-subarkah", expected: "marwan-subarkah"},
 	}
 
 	dictionary := NewDictionary("hancur", "benar", "apa", "siapa", "jubah",
@@ -184,7 +198,8 @@ func TestStemmer(t *testing.T) {
 		"laku", "baik", "terang", "iman", "bisik", "taat", "puas", "makan",
 		"nyala", "nyanyi", "nyata", "nyawa", "rata", "lembut", "ligas",
 		"budaya", "karya", "ideal", "final", "taat", "tiru", "sepak", "kuasa",
-		"malaikat", "nikmat", "lewat", "nganga", "allah",
+		"malaikat", "nikmat", "lewat", "nganga", "allah", "anak", "lari",
+		"sayur", "balik", "kupu-kupu",
 	)
 
 	stemmer := NewStemmer(dictionary)
@@ -196,3 +211,146 @@ func TestStemmer(t *testing.T) {
 		}
 	}
 }
+
+func TestStemmerReduplication(t *testing.T) {
+	stemmer := NewStemmer(NewDictionary("kupu-kupu", "kupu", "buku"))
+	stemmer.KeepReduplication(false)
+
+	for _, item := range []testItem{
+		{value: "kupu-kupu", expected: "kupu"},
+		{value: "kupu-kupunya", expected: "kupu"},
+		{value: "buku-buku", expected: "buku"},
+	} {
+		result := stemmer.Stem(item.value)
+		if result != item.expected {
+			t.Errorf("%s, expected: %s, result: %s", item.value, item.expected, result)
+		}
+	}
+
+	analysis := stemmer.Analyze("buku-bukunya")
+	expected := Analysis{Word: "buku-bukunya", Root: "buku", Possessive: "nya", Found: true, Reduplicated: true}
+	if !reflect.DeepEqual(analysis, expected) {
+		t.Errorf("buku-bukunya, expected: %+v, result: %+v", expected, analysis)
+	}
+
+	// Reduplicated word whose root is not found is returned as it is
+	analysis = stemmer.Analyze("xyz-xyz")
+	expected = Analysis{Word: "xyz-xyz", Root: "xyz-xyz"}
+	if !reflect.DeepEqual(analysis, expected) {
+		t.Errorf("xyz-xyz, expected: %+v, result: %+v", expected, analysis)
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	testItems := []Analysis{
+		{Word: "keberuntunganmu", Root: "untung", Prefixes: []string{"ke", "ber"}, Suffix: "an", Possessive: "mu", Found: true},
+		{Word: "memukul", Root: "pukul", Prefixes: []string{"mem"}, Recoding: "p", Found: true},
+		{Word: "menyuarakan", Root: "suara", Prefixes: []string{"meny"}, Suffix: "kan", Recoding: "s", Found: true},
+		{Word: "memperbaiki", Root: "baik", Prefixes: []string{"mem", "per"}, Suffix: "i", Found: true},
+		{Word: "bukumukah", Root: "buku", Possessive: "mu", Particle: "kah", Found: true},
+		{Word: "kuasa-mu", Root: "kuasa", Possessive: "mu", Found: true},
+		{Word: "lemigas", Root: "ligas", Infix: "em", Found: true},
+		{Word: "menggeletar", Root: "getar", Prefixes: []string{"meng"}, Infix: "el", Found: true},
+		{Word: "bertebaran", Root: "tebar", Prefixes: []string{"ber"}, Suffix: "an", Found: true},
+		{Word: "buku", Root: "buku", Found: true},
+		{Word: "marwan", Root: "marwan", Found: false},
+	}
+
+	dictionary := NewDictionary("untung", "pukul", "suara", "baik", "buku",
+		"kuasa", "ligas", "getar", "tebar")
+	stemmer := NewStemmer(dictionary)
+
+	for _, item := range testItems {
+		result := stemmer.Analyze(item.Word)
+		if !reflect.DeepEqual(result, item) {
+			t.Errorf("%s, expected: %+v, result: %+v", item.Word, item, result)
+		}
+
+		if rebuilt := rebuildWord(result); rebuilt != strings.Replace(item.Word, "-", "", -1) {
+			t.Errorf("%s, affixes rebuild: %s", item.Word, rebuilt)
+		}
+	}
+
+	// The prefixes from failed attempt must not be left when the root found later
+	stemmer = NewStemmer(DefaultDictionary())
+	for _, word := range []string{"diskotekan", "kelubakan", "pelatukan", "seksmaniakan",
+		"keberuntunganmu", "menyuarakan", "pembangunan", "mempermainkan"} {
+		result := stemmer.Analyze(word)
+		if rebuilt := rebuildWord(result); rebuilt != word {
+			t.Errorf("%s, affixes rebuild: %s, result: %+v", word, rebuilt, result)
+		}
+	}
+}
+
+// rebuildWord joins the affixes and root in analysis back into the analyzed word
+func rebuildWord(analysis Analysis) string {
+	root := strings.TrimPrefix(analysis.Root, analysis.Recoding)
+	if analysis.Infix != "" {
+		n := strings.IndexAny(root, "aiueo")
+		root = root[:n] + analysis.Infix + root[n:]
+	}
+
+	return strings.Join(analysis.Prefixes, "") + root +
+		analysis.Suffix + analysis.Possessive + analysis.Particle
+}
+
+func TestStemTrace(t *testing.T) {
+	stemmer := NewStemmer(NewDictionary("ligas", "bangun"))
+
+	analysis, trace := stemmer.StemTrace("lemigas")
+	if analysis.Root != "ligas" || analysis.Infix != "em" || trace.Root != "ligas" || !trace.Found {
+		t.Fatalf("lemigas, expected: l-em-igas, result: %+v", analysis)
+	}
+
+	expected := []TraceStep{
+		{Action: TraceRemoveInfix, Rule: "infix-1", Input: "lemigas", Output: "igas"},
+		{Action: TraceLookup, Input: "igas"},
+		{Action: TraceRecoding, Rule: "lem", Input: "igas", Output: "lemigas"},
+		{Action: TraceRecoding, Rule: "l", Input: "igas", Output: "ligas", Found: true},
+	}
+
+	steps := trace.Steps[len(trace.Steps)-len(expected):]
+	if !reflect.DeepEqual(steps, expected) {
+		t.Errorf("lemigas, expected steps: %+v, result: %+v", expected, steps)
+	}
+
+	_, trace = stemmer.StemTrace("pembangunan")
+	rendered := trace.String()
+	for _, line := range []string{
+		`branch suffix-first: "pembangunan"`,
+		`remove suffix: "pembangunan" => "pembangun"`,
+		`remove prefix pe-5: "pembangun" => "bangun"`,
+		`root "bangun": found`,
+	} {
+		if !strings.Contains(rendered, line) {
+			t.Errorf("pembangunan, expected trace to contain %q, result:\n%s", line, rendered)
+		}
+	}
+}
+
+func TestStemmerRootLookup(t *testing.T) {
+	legal := NewDictionary("gugat", "dakwa")
+	medical := LookupFunc(func(word string) bool {
+		return word == "diagnosis" || word == "rawat"
+	})
+
+	stemmer := NewStemmer(MultiLookup{legal, medical})
+	for _, item := range []testItem{
+		{value: "menggugat", expected: "gugat"},
+		{value: "terdakwa", expected: "dakwa"},
+		{value: "perawatan", expected: "rawat"},
+		{value: "makanan", expected: "makanan"},
+	} {
+		result := stemmer.Stem(item.value)
+		if result != item.expected {
+			t.Errorf("%s, expected: %s, result: %s", item.value, item.expected, result)
+		}
+	}
+
+	// Stemmer without dictionary treats it as empty, like the nil Dictionary map
+	for _, word := range []string{"memukul", "makanan"} {
+		if result := (Stemmer{}).Stem(word); result != word {
+			t.Errorf("%s with zero Stemmer, expected: %s, result: %s", word, word, result)
+		}
+	}
+}
@@ -0,0 +1,402 @@
+package sastrawi
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TokenType is the kind of Token
+type TokenType int
+
+// List of token types
+const (
+	TokenWord TokenType = iota
+	TokenNumber
+	TokenURL
+	TokenEmail
+	TokenMention
+	TokenHashtag
+	TokenEmoji
+	TokenPunct
+)
+
+var tokenTypeNames = []string{"WORD", "NUMBER", "URL", "EMAIL", "MENTION", "HASHTAG", "EMOJI", "PUNCT"}
+
+func (tokenType TokenType) String() string {
+	if tokenType < 0 || int(tokenType) >= len(tokenTypeNames) {
+		return fmt.Sprintf("TokenType(%d)", int(tokenType))
+	}
+
+	return tokenTypeNames[tokenType]
+}
+
+// MarshalText encodes token type as its name, e.g. "WORD"
+func (tokenType TokenType) MarshalText() ([]byte, error) {
+	return []byte(tokenType.String()), nil
+}
+
+// UnmarshalText decodes token type from its name
+func (tokenType *TokenType) UnmarshalText(text []byte) error {
+	for i, name := range tokenTypeNames {
+		if name == string(text) {
+			*tokenType = TokenType(i)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown token type %q", text)
+}
+
+// Token is a word that found in text, along with its position in the text
+type Token struct {
+	// Text is the normalized form of the token, i.e. the one returned by Tokenize
+	Text string `json:"text"`
+
+	// Type is the kind of the token
+	Type TokenType `json:"type"`
+
+	// Surface is the token as it's written in the original text
+	Surface string `json:"surface"`
+
+	// Start and End are the byte offsets of Surface in the original text
+	Start int `json:"start"`
+	End   int `json:"end"`
+
+	// StartRune and EndRune are the character offsets of Surface in the original text
+	StartRune int `json:"start_rune"`
+	EndRune   int `json:"end_rune"`
+
+	// Position is the index of token in the tokenizer's output. It's kept as it is by
+	// token filters, so removed tokens leave gaps between the positions.
+	Position int `json:"position"`
+}
+
+// setRuneOffsets fills the character offsets of tokens, which must be sorted by their position.
+// Tokens that decoded from the same HTML entity share the same original range, so they might
+// overlap, e.g. punctuation and combining mark from "&nvlt;".
+func setRuneOffsets(text string, tokens []Token) {
+	offset, runeOffset := 0, 0
+	for i := range tokens {
+		if tokens[i].Start < offset {
+			offset, runeOffset = tokens[i-1].Start, tokens[i-1].StartRune
+		}
+
+		runeOffset += utf8.RuneCountInString(text[offset:tokens[i].Start])
+		tokens[i].StartRune = runeOffset
+
+		runeOffset += utf8.RuneCountInString(text[tokens[i].Start:tokens[i].End])
+		tokens[i].EndRune = runeOffset
+		offset = tokens[i].End
+	}
+}
+
+// mappedText is text that remembers where each of its bytes comes from in the
+// original text, so it can be normalized while keeping the original offsets.
+// Byte text[i] comes from original[starts[i]:ends[i]].
+type mappedText struct {
+	original string
+	text     string
+	starts   []int
+	ends     []int
+}
+
+// mappedTextBuilder is used to build new mappedText from another mappedText
+type mappedTextBuilder struct {
+	src    mappedText
+	text   strings.Builder
+	starts []int
+	ends   []int
+}
+
+func newMappedText(text string) mappedText {
+	starts := make([]int, len(text))
+	ends := make([]int, len(text))
+	for i := 0; i < len(text); i++ {
+		starts[i] = i
+		ends[i] = i + 1
+	}
+
+	return mappedText{original: text, text: text, starts: starts, ends: ends}
+}
+
+// toLower converts text to lower case in the same way as strings.ToLower
+func (mt mappedText) toLower() mappedText {
+	builder := mt.builder()
+	for i := 0; i < len(mt.text); {
+		r, size := utf8.DecodeRuneInString(mt.text[i:])
+		if lower := unicode.ToLower(r); lower != r || r == utf8.RuneError {
+			builder.replace(i, i+size, string(lower))
+		} else {
+			builder.copy(i, i+size)
+		}
+
+		i += size
+	}
+
+	return builder.build()
+}
+
+// unescapeHTML decodes HTML entities in text in the same way as html.UnescapeString
+func (mt mappedText) unescapeHTML() mappedText {
+	if !strings.Contains(mt.text, "&") {
+		return mt
+	}
+
+	builder := mt.builder()
+	start := strings.IndexByte(mt.text, '&')
+	builder.copy(0, start)
+
+	// Each entity starts with '&' and never contains another '&', so each chunk
+	// from '&' until the next one can be decoded separately. The decoded chunk
+	// is the decoded entity followed by the rest of chunk as it is.
+	for start < len(mt.text) {
+		end := strings.IndexByte(mt.text[start+1:], '&')
+		if end < 0 {
+			end = len(mt.text)
+		} else {
+			end += start + 1
+		}
+
+		chunk := mt.text[start:end]
+		decoded := html.UnescapeString(chunk)
+
+		nRest := 0
+		for nRest < len(decoded) && nRest < len(chunk) &&
+			decoded[len(decoded)-1-nRest] == chunk[len(chunk)-1-nRest] {
+			nRest++
+		}
+
+		if end-nRest > start {
+			builder.replace(start, end-nRest, decoded[:len(decoded)-nRest])
+		}
+
+		builder.copy(end-nRest, end)
+		start = end
+	}
+
+	return builder.build()
+}
+
+// removeAll removes all text that matched by rx
+func (mt mappedText) removeAll(rx *regexp.Regexp) mappedText {
+	matches := rx.FindAllStringIndex(mt.text, -1)
+	if len(matches) == 0 {
+		return mt
+	}
+
+	builder := mt.builder()
+	lastEnd := 0
+	for _, match := range matches {
+		builder.copy(lastEnd, match[0])
+		lastEnd = match[1]
+	}
+
+	builder.copy(lastEnd, len(mt.text))
+	return builder.build()
+}
+
+// extract removes all text that matched by rx, and returns the ones accepted by keep as tokens
+func (mt mappedText) extract(rx *regexp.Regexp, tokenType TokenType, keep func(string) bool) (mappedText, []Token) {
+	matches := rx.FindAllStringIndex(mt.text, -1)
+	if len(matches) == 0 {
+		return mt, nil
+	}
+
+	tokens := []Token{}
+	for _, match := range matches {
+		if keep(mt.text[match[0]:match[1]]) {
+			token := mt.token(match[0], match[1])
+			token.Type = tokenType
+			tokens = append(tokens, token)
+		}
+	}
+
+	return mt.removeAll(rx), tokens
+}
+
+// splitWords returns the byte ranges of words in text. Word is a sequence of
+// characters that accepted by isWordChar. Character that accepted by isJoiner
+// is also included if it's placed between two word characters.
+func (mt mappedText) splitWords(isWordChar func(rune) bool, isJoiner func(prev, r, next rune) bool) [][2]int {
+	spans := [][2]int{}
+	start := -1
+	prev := rune(0)
+
+	for i, r := range mt.text {
+		if isWordChar(r) {
+			if start < 0 {
+				start = i
+			}
+		} else if start >= 0 {
+			next := mt.runeAfter(i)
+			if isJoiner == nil || !isWordChar(next) || !isJoiner(prev, r, next) {
+				spans = append(spans, [2]int{start, i})
+				start = -1
+			}
+		}
+
+		prev = r
+	}
+
+	if start >= 0 {
+		spans = append(spans, [2]int{start, len(mt.text)})
+	}
+
+	return spans
+}
+
+// symbols returns every character outside of words as emoji or punctuation token.
+// Emoji that consists of several characters, e.g. flag or emoji with skin tone,
+// is returned as single token.
+func (mt mappedText) symbols(words [][2]int) []Token {
+	tokens := []Token{}
+	for i := 0; i < len(mt.text); {
+		if len(words) > 0 && i >= words[0][0] {
+			i = words[0][1]
+			words = words[1:]
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(mt.text[i:])
+		if unicode.IsSpace(r) || isSpaceLike(r) {
+			i += size
+			continue
+		}
+
+		end := i + size
+		tokenType := TokenPunct
+		if isEmoji(r) {
+			tokenType = TokenEmoji
+			end = mt.emojiEnd(r, end)
+		}
+
+		// Characters that decoded from the same HTML entity, e.g. "&nvlt;", are kept together
+		for end < len(mt.text) && mt.starts[end] < mt.ends[end-1] {
+			end++
+		}
+
+		// Don't go through the next word
+		if len(words) > 0 && end > words[0][0] {
+			end = words[0][0]
+		}
+
+		token := mt.token(i, end)
+		token.Type = tokenType
+		tokens = append(tokens, token)
+		i = end
+	}
+
+	return tokens
+}
+
+// emojiEnd returns the end of emoji sequence that starts with r and continues from text[i]
+func (mt mappedText) emojiEnd(r rune, i int) int {
+	isRegional := r >= 0x1F1E6 && r <= 0x1F1FF
+	for i < len(mt.text) {
+		next, size := utf8.DecodeRuneInString(mt.text[i:])
+		switch {
+		case next == 0xFE0F || next == 0x20E3 || next >= 0x1F3FB && next <= 0x1F3FF:
+			// Variation selector, keycap or skin tone modifier
+			i += size
+
+		case next == 0x200D:
+			// Zero width joiner, followed by another emoji
+			joined, joinedSize := utf8.DecodeRuneInString(mt.text[i+size:])
+			if !isEmoji(joined) {
+				return i
+			}
+
+			i += size + joinedSize
+
+		case isRegional && next >= 0x1F1E6 && next <= 0x1F1FF:
+			// Flag is a pair of regional indicators
+			i += size
+			isRegional = false
+
+		default:
+			return i
+		}
+	}
+
+	return i
+}
+
+// isEmoji checks if r is within the common emoji blocks
+func isEmoji(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF, // Pictographs, emoticons, transport, flags, etc.
+		r >= 0x2600 && r <= 0x27BF, // Miscellaneous symbols and dingbats
+		r >= 0x2300 && r <= 0x23FF, // Miscellaneous technical, e.g. watch and hourglass
+		r >= 0x2B00 && r <= 0x2BFF, // Arrows and stars
+		r == 0x3030 || r == 0x303D || r == 0x3297 || r == 0x3299:
+		return true
+	default:
+		return false
+	}
+}
+
+// runeAfter returns the character after the one that starts at text[i], or 0 if it's the last character
+func (mt mappedText) runeAfter(i int) rune {
+	_, size := utf8.DecodeRuneInString(mt.text[i:])
+	if i+size >= len(mt.text) {
+		return 0
+	}
+
+	r, _ := utf8.DecodeRuneInString(mt.text[i+size:])
+	return r
+}
+
+// token creates Token from text[start:end]
+func (mt mappedText) token(start, end int) Token {
+	originalStart := mt.starts[start]
+	originalEnd := mt.ends[end-1]
+	return Token{
+		Text:    mt.text[start:end],
+		Surface: mt.original[originalStart:originalEnd],
+		Start:   originalStart,
+		End:     originalEnd,
+	}
+}
+
+func (mt mappedText) builder() *mappedTextBuilder {
+	builder := &mappedTextBuilder{
+		src:    mt,
+		starts: make([]int, 0, len(mt.text)),
+		ends:   make([]int, 0, len(mt.text)),
+	}
+
+	builder.text.Grow(len(mt.text))
+	return builder
+}
+
+// copy copies src.text[start:end] as it is
+func (builder *mappedTextBuilder) copy(start, end int) {
+	builder.text.WriteString(builder.src.text[start:end])
+	builder.starts = append(builder.starts, builder.src.starts[start:end]...)
+	builder.ends = append(builder.ends, builder.src.ends[start:end]...)
+}
+
+// replace writes replacement in place of src.text[start:end], which must not be empty
+func (builder *mappedTextBuilder) replace(start, end int, replacement string) {
+	originalStart := builder.src.starts[start]
+	originalEnd := builder.src.ends[end-1]
+
+	builder.text.WriteString(replacement)
+	for i := 0; i < len(replacement); i++ {
+		builder.starts = append(builder.starts, originalStart)
+		builder.ends = append(builder.ends, originalEnd)
+	}
+}
+
+func (builder *mappedTextBuilder) build() mappedText {
+	return mappedText{
+		original: builder.src.original,
+		text:     builder.text.String(),
+		starts:   builder.starts,
+		ends:     builder.ends,
+	}
+}
@@ -0,0 +1,193 @@
+package sastrawi
+
+import (
+	"bytes"
+	"io"
+	"unicode/utf8"
+)
+
+// DefaultMaxLineSize is the default maximum size of line that buffered by TokenScanner
+const DefaultMaxLineSize = 1024 * 1024
+
+// maxConsecutiveEmptyReads is the number of reads without data and error that
+// allowed before TokenScanner gives up, like in bufio.Scanner
+const maxConsecutiveEmptyReads = 100
+
+// TokenScanner reads tokens from io.Reader one by one, like bufio.Scanner. It reads
+// the text line by line, so the memory it uses is bounded by the longest line instead
+// of the whole text. Since Tokenize never joins text across line break, the tokens
+// are the same as the ones returned by TokenizeWithOffsets for the whole text, as
+// long as every line fits into the buffer.
+//
+// Line that longer than the maximum line size is cut at its last ASCII whitespace,
+// or at the last complete character if it doesn't have any whitespace. In that case
+// the tokens might differ around the cut, e.g. a word or URL that longer than the
+// buffer might be split, and HTML escape like "&amp;" that removed by tokenizer
+// might be kept when the line is cut between "&" and ";".
+type TokenScanner struct {
+	tokenizer   Tokenizer
+	reader      io.Reader
+	maxLineSize int
+
+	buffer     []byte
+	eof        bool
+	err        error
+	tokens     []Token
+	token      Token
+	offset     int
+	runeOffset int
+	position   int
+}
+
+// NewTokenScanner returns scanner that reads tokens from r in the same way as Tokenize
+func NewTokenScanner(r io.Reader) *TokenScanner {
+	return Tokenizer{}.NewScanner(r)
+}
+
+// NewScanner returns scanner that reads tokens from r according to the tokenizer's options
+func (tokenizer Tokenizer) NewScanner(r io.Reader) *TokenScanner {
+	return &TokenScanner{
+		tokenizer:   tokenizer,
+		reader:      r,
+		maxLineSize: DefaultMaxLineSize,
+	}
+}
+
+// Buffer sets the maximum size of line that buffered by scanner. It must be called before Scan.
+func (scanner *TokenScanner) Buffer(maxLineSize int) {
+	if maxLineSize < utf8.UTFMax {
+		maxLineSize = utf8.UTFMax
+	}
+
+	scanner.maxLineSize = maxLineSize
+}
+
+// Scan advances scanner to the next token, which will be available through Token and
+// Text. It returns false when there are no more tokens, either because the reader
+// reaches EOF or an error occurred.
+func (scanner *TokenScanner) Scan() bool {
+	for len(scanner.tokens) == 0 {
+		line, ok := scanner.readLine()
+		if !ok {
+			return false
+		}
+
+		scanner.tokens = scanner.tokenizer.TokenizeWithOffsets(line)
+		for i := range scanner.tokens {
+			scanner.tokens[i].Start += scanner.offset
+			scanner.tokens[i].End += scanner.offset
+			scanner.tokens[i].StartRune += scanner.runeOffset
+			scanner.tokens[i].EndRune += scanner.runeOffset
+			scanner.tokens[i].Position += scanner.position
+		}
+
+		scanner.position += len(scanner.tokens)
+		scanner.offset += len(line)
+		scanner.runeOffset += utf8.RuneCountInString(line)
+	}
+
+	scanner.token = scanner.tokens[0]
+	scanner.tokens = scanner.tokens[1:]
+
+	return true
+}
+
+// Token returns the most recent token generated by Scan
+func (scanner *TokenScanner) Token() Token {
+	return scanner.token
+}
+
+// Text returns the normalized text of the most recent token generated by Scan
+func (scanner *TokenScanner) Text() string {
+	return scanner.token.Text
+}
+
+// Err returns the first non EOF error that encountered by scanner
+func (scanner *TokenScanner) Err() error {
+	return scanner.err
+}
+
+// readLine returns the next line, including its line break
+func (scanner *TokenScanner) readLine() (string, bool) {
+	searched := 0
+	for {
+		if idx := bytes.IndexByte(scanner.buffer[searched:], '\n'); idx >= 0 {
+			return scanner.consume(searched + idx + 1), true
+		}
+
+		searched = len(scanner.buffer)
+		if len(scanner.buffer) >= scanner.maxLineSize {
+			return scanner.consume(scanner.cutPosition()), true
+		}
+
+		if scanner.eof {
+			if len(scanner.buffer) == 0 {
+				return "", false
+			}
+
+			return scanner.consume(len(scanner.buffer)), true
+		}
+
+		scanner.fill()
+	}
+}
+
+// fill reads more data from reader into buffer
+func (scanner *TokenScanner) fill() {
+	if cap(scanner.buffer) == len(scanner.buffer) {
+		size := 2*cap(scanner.buffer) + 4096
+		if size > scanner.maxLineSize {
+			size = scanner.maxLineSize
+		}
+
+		buffer := make([]byte, len(scanner.buffer), size)
+		copy(buffer, scanner.buffer)
+		scanner.buffer = buffer
+	}
+
+	for i := 0; i < maxConsecutiveEmptyReads; i++ {
+		n, err := scanner.reader.Read(scanner.buffer[len(scanner.buffer):cap(scanner.buffer)])
+		scanner.buffer = scanner.buffer[:len(scanner.buffer)+n]
+
+		if err != nil {
+			scanner.eof = true
+			if err != io.EOF {
+				scanner.err = err
+			}
+
+			return
+		}
+
+		if n > 0 {
+			return
+		}
+	}
+
+	scanner.eof = true
+	scanner.err = io.ErrNoProgress
+}
+
+// cutPosition returns where a line that doesn't fit into buffer should be cut
+func (scanner *TokenScanner) cutPosition() int {
+	line := scanner.buffer[:scanner.maxLineSize]
+	if idx := bytes.LastIndexAny(line, " \t\r\f\v"); idx >= 0 {
+		return idx + 1
+	}
+
+	// Don't cut in the middle of a character
+	for i := len(line); i > len(line)-utf8.UTFMax; i-- {
+		if i == len(scanner.buffer) || utf8.RuneStart(scanner.buffer[i]) {
+			return i
+		}
+	}
+
+	return len(line)
+}
+
+// consume removes the first n bytes of buffer and returns them as string
+func (scanner *TokenScanner) consume(n int) string {
+	line := string(scanner.buffer[:n])
+	scanner.buffer = scanner.buffer[:copy(scanner.buffer, scanner.buffer[n:])]
+
+	return line
+}
@@ -0,0 +1,142 @@
+package sastrawi
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// diacriticFolds maps letter with diacritic into its ASCII form. It covers
+// Latin-1, Latin Extended-A and letters that used in transliteration of Arabic.
+var diacriticFolds = buildDiacriticFolds(map[string]string{
+	"a":  "àáâãäåāăąǎạảấầẩẫậắằẳẵặ",
+	"ae": "æǽ",
+	"c":  "çćĉċč",
+	"d":  "ďđðḍḏ",
+	"e":  "èéêëēĕėęěẹẻẽếềểễệ",
+	"g":  "ĝğġģǧ",
+	"h":  "ĥħḥḫẖ",
+	"i":  "ìíîïĩīĭįıǐỉị",
+	"j":  "ĵ",
+	"k":  "ķ",
+	"l":  "ĺļľŀł",
+	"n":  "ñńņňŉ",
+	"o":  "òóôõöøōŏőǒọỏốồổỗộớờởỡợơ",
+	"oe": "œ",
+	"r":  "ŕŗř",
+	"s":  "śŝşšșṣ",
+	"ss": "ß",
+	"t":  "ţťŧțṭṯ",
+	"th": "þ",
+	"u":  "ùúûüũūŭůűųǔụủứừửữựư",
+	"w":  "ŵ",
+	"y":  "ýÿŷỳỵỷỹ",
+	"z":  "źżžẓẕ",
+})
+
+func buildDiacriticFolds(groups map[string]string) map[rune]string {
+	folds := map[rune]string{}
+	for ascii, letters := range groups {
+		for _, r := range letters {
+			folds[r] = ascii
+			if upper := unicode.ToUpper(r); upper != r {
+				folds[upper] = strings.ToUpper(ascii)
+			}
+		}
+	}
+
+	return folds
+}
+
+// foldDiacritics converts letters with diacritic in word into ASCII, e.g. "café"
+// into "cafe", and removes combining marks, apostrophes and soft hyphens.
+func foldDiacritics(word string) string {
+	if isASCII(word) && !strings.Contains(word, "'") {
+		return word
+	}
+
+	builder := strings.Builder{}
+	for _, r := range word {
+		switch {
+		case diacriticFolds[r] != "":
+			builder.WriteString(diacriticFolds[r])
+		case isApostrophe(r), isModifierQuote(r), r == '\u00AD', unicode.Is(unicode.Mn, r):
+		default:
+			builder.WriteRune(r)
+		}
+	}
+
+	return builder.String()
+}
+
+// normalizeApostrophes replaces curly apostrophes in word with ASCII apostrophe,
+// and removes soft hyphens
+func normalizeApostrophes(word string) string {
+	if isASCII(word) {
+		return word
+	}
+
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '\u00AD':
+			return -1
+		case isApostrophe(r):
+			return '\''
+		default:
+			return r
+		}
+	}, word)
+}
+
+// normalizeSpaces replaces non-breaking and zero width spaces with ordinary space,
+// so they can separate words, URLs and hashtags
+func (mt mappedText) normalizeSpaces() mappedText {
+	if isASCII(mt.text) {
+		return mt
+	}
+
+	builder := mt.builder()
+	for i := 0; i < len(mt.text); {
+		r, size := utf8.DecodeRuneInString(mt.text[i:])
+		if isSpaceLike(r) {
+			builder.replace(i, i+size, " ")
+		} else {
+			builder.copy(i, i+size)
+		}
+
+		i += size
+	}
+
+	return builder.build()
+}
+
+func isASCII(text string) bool {
+	for i := 0; i < len(text); i++ {
+		if text[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isSpaceLike checks if r is non ASCII space, including the invisible ones
+func isSpaceLike(r rune) bool {
+	switch r {
+	case '\u00A0', '\u2007', '\u202F', '\u200B', '\u2060', '\uFEFF':
+		return true
+	default:
+		return r >= utf8.RuneSelf && unicode.IsSpace(r)
+	}
+}
+
+// isApostrophe checks if r is ASCII or curly apostrophe
+func isApostrophe(r rune) bool {
+	return r == '\'' || r == '\u2019' || r == '\u2018' || r == '\u02BC'
+}
+
+// isModifierQuote checks if r is modifier letter that used as quote in
+// transliteration, e.g. ʿ for 'ain and ʾ for hamzah
+func isModifierQuote(r rune) bool {
+	return r == 'ʾ' || r == 'ʿ'
+}
@@ -2,9 +2,53 @@ package sastrawi
 
 import (
 	"html"
+	"sort"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
+// Tokenizer splits text into words, with options to keep some parts of text that
+// removed by Tokenize. The zero value of Tokenizer behaves exactly like Tokenize.
+type Tokenizer struct {
+	// KeepNumbers keeps numbers, e.g. "2020" or "1.500,50", and words that
+	// contain digits, e.g. "covid19"
+	KeepNumbers bool
+
+	// KeepHashtags keeps hashtags, e.g. "#beritahariini"
+	KeepHashtags bool
+
+	// KeepMentions keeps Twitter style mentions, e.g. "@kompascom"
+	KeepMentions bool
+
+	// KeepHyphens keeps hyphenated words together, e.g. "anak-anak" and "kuasa-mu"
+	KeepHyphens bool
+
+	// KeepURLs keeps URLs as single token
+	KeepURLs bool
+
+	// KeepEmails keeps email addresses as single token
+	KeepEmails bool
+
+	// KeepCase disables converting text to lower case
+	KeepCase bool
+
+	// Unicode treats letters of every script as word characters, e.g. "café" and
+	// "gödel", instead of only ASCII letters. Apostrophe between letters is kept
+	// as part of word, e.g. "jum'at", and non-breaking spaces separate words.
+	Unicode bool
+
+	// FoldDiacritics converts letters with diacritic into ASCII and removes
+	// apostrophes, e.g. "café" into "cafe" and "jum'at" into "jumat", so the
+	// words can be found in dictionary. It implies Unicode.
+	FoldDiacritics bool
+
+	// Classify keeps every part of text as typed token instead of removing it,
+	// including emoji and punctuation. It implies KeepNumbers, KeepHashtags,
+	// KeepMentions, KeepURLs and KeepEmails.
+	Classify bool
+}
+
 // Tokenize remove symbols and URLs from sentence, then split it into words
 func Tokenize(sentence string) []string {
 	// Normalize sentence and remove all symbol
@@ -19,3 +63,155 @@ func Tokenize(sentence string) []string {
 
 	return strings.Fields(sentence)
 }
+
+// TokenizeWithOffsets is like Tokenize, but also returns the position
+// and the original form of each token in sentence
+func TokenizeWithOffsets(sentence string) []Token {
+	return Tokenizer{}.TokenizeWithOffsets(sentence)
+}
+
+// Tokenize splits sentence into words according to the tokenizer's options
+func (tokenizer Tokenizer) Tokenize(sentence string) []string {
+	tokens := tokenizer.TokenizeWithOffsets(sentence)
+	words := make([]string, len(tokens))
+	for i, token := range tokens {
+		words[i] = token.Text
+	}
+
+	return words
+}
+
+// TokenizeWithOffsets is like Tokenize, but also returns the position
+// and the original form of each token in sentence
+func (tokenizer Tokenizer) TokenizeWithOffsets(sentence string) []Token {
+	if tokenizer.FoldDiacritics {
+		tokenizer.Unicode = true
+	}
+
+	text := newMappedText(sentence)
+	if tokenizer.Unicode {
+		text = text.normalizeSpaces()
+	}
+
+	if !tokenizer.KeepCase {
+		text = text.toLower()
+	}
+
+	text = text.unescapeHTML()
+
+	if tokenizer.Classify {
+		tokenizer.KeepNumbers = true
+		tokenizer.KeepHashtags = true
+		tokenizer.KeepMentions = true
+		tokenizer.KeepURLs = true
+		tokenizer.KeepEmails = true
+	}
+
+	var urls, emails, twitters []Token
+	text, urls = text.extract(rxURL, TokenURL, func(string) bool {
+		return tokenizer.KeepURLs
+	})
+
+	text, emails = text.extract(rxEmail, TokenEmail, func(string) bool {
+		return tokenizer.KeepEmails
+	})
+
+	text, twitters = text.extract(rxTwitter, TokenMention, func(match string) bool {
+		return match[0] == '#' && tokenizer.KeepHashtags ||
+			match[0] == '@' && tokenizer.KeepMentions
+	})
+
+	for i := range twitters {
+		if twitters[i].Text[0] == '#' {
+			twitters[i].Type = TokenHashtag
+		}
+	}
+
+	if !tokenizer.Classify {
+		text = text.removeAll(rxEscapeStr)
+	}
+
+	tokens := []Token{}
+	spans := text.splitWords(tokenizer.isWordChar, tokenizer.isJoiner)
+	for _, span := range spans {
+		token := text.token(span[0], span[1])
+		if isNumber(token.Text) {
+			token.Type = TokenNumber
+		}
+
+		if tokenizer.FoldDiacritics {
+			token.Text = foldDiacritics(token.Text)
+		} else if tokenizer.Unicode {
+			token.Text = normalizeApostrophes(token.Text)
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	var symbols []Token
+	if tokenizer.Classify {
+		symbols = text.symbols(spans)
+	}
+
+	if len(urls)+len(emails)+len(twitters)+len(symbols) > 0 {
+		tokens = append(tokens, urls...)
+		tokens = append(tokens, emails...)
+		tokens = append(tokens, twitters...)
+		tokens = append(tokens, symbols...)
+		sort.SliceStable(tokens, func(i, j int) bool {
+			return tokens[i].Start < tokens[j].Start
+		})
+	}
+
+	setRuneOffsets(sentence, tokens)
+	for i := range tokens {
+		tokens[i].Position = i
+	}
+
+	return tokens
+}
+
+// isWordChar checks if r is part of word. By default, it's the letters
+// that matched by rxSymbol, which includes 'ſ' and Kelvin sign 'K'
+// since rxSymbol is case insensitive.
+func (tokenizer Tokenizer) isWordChar(r rune) bool {
+	switch {
+	case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == 'ſ', r == 'K':
+		return true
+	case r >= '0' && r <= '9':
+		return tokenizer.KeepNumbers
+	case r >= utf8.RuneSelf && tokenizer.Unicode:
+		return unicode.IsLetter(r) || unicode.Is(unicode.Mn, r)
+	default:
+		return false
+	}
+}
+
+// isJoiner checks if r, which placed between two word characters prev and next, is part of word
+func (tokenizer Tokenizer) isJoiner(prev, r, next rune) bool {
+	switch r {
+	case '-':
+		return tokenizer.KeepHyphens
+	case '.', ',':
+		return tokenizer.KeepNumbers && isDigit(prev) && isDigit(next)
+	case '\'', '\u2019', '\u2018', '\u00AD':
+		return tokenizer.Unicode && unicode.IsLetter(prev) && unicode.IsLetter(next)
+	default:
+		return false
+	}
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+// isNumber checks if word only contains digits and separators
+func isNumber(word string) bool {
+	for _, r := range word {
+		if !isDigit(r) && r != '.' && r != ',' {
+			return false
+		}
+	}
+
+	return word != ""
+}
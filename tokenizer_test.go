@@ -0,0 +1,295 @@
+package sastrawi
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"unicode/utf8"
+)
+
+var tokenizerTestSentences = []string{
+	"Rakyat memenuhi halaman gedung untuk menyuarakan isi hatinya. Baca berita selengkapnya di http://www.kompas.com.",
+	"Perekonomian Indonesia sedang dalam pertumbuhan yang membanggakan",
+	"Hubungi kami di redaksi@kompas.com atau @kompascom #BeritaHariIni",
+	"Harga naik 10% &amp; stok &lt;terbatas&gt;, kata Bpk. Budi &copy; 2020",
+	"AT&T; mengumumkan &unknown; sesuatu & lainnya; lalu selesai",
+	"Anak-anak bermain di kuasa-Mu, Jum’at pagi",
+	"Café, Gödel dan İstanbul adalah ſesuatu yang Keren\vsekali",
+	"baris pertama\nbaris kedua &#10; baris ketiga\r\nbaris keempat",
+	"invalid \xff\xfe utf8 dan &#xFFFD; karakter",
+	"ftp://files.example.com/a.txt wwwkompas dan https",
+	"",
+	"   ",
+}
+
+func TestTokenizeWithOffsets(t *testing.T) {
+	for _, sentence := range tokenizerTestSentences {
+		expected := Tokenize(sentence)
+		tokens := TokenizeWithOffsets(sentence)
+
+		texts := []string{}
+		for _, token := range tokens {
+			texts = append(texts, token.Text)
+		}
+
+		if !reflect.DeepEqual(texts, expected) {
+			t.Errorf("%q\nexpected: %q\nresult:   %q", sentence, expected, texts)
+		}
+
+		for _, token := range tokens {
+			if sentence[token.Start:token.End] != token.Surface {
+				t.Errorf("%q, expected surface of %q: %q, result: %q",
+					sentence, token.Text, sentence[token.Start:token.End], token.Surface)
+			}
+
+			if !strings.Contains(token.Surface, "&") && utf8.ValidString(token.Surface) &&
+				strings.ToLower(token.Surface) != token.Text {
+				t.Errorf("%q, surface %q doesn't match %q", sentence, token.Surface, token.Text)
+			}
+
+			runes := []rune(sentence)
+			if string(runes[token.StartRune:token.EndRune]) != token.Surface && utf8.ValidString(sentence) {
+				t.Errorf("%q, expected rune offsets of %q to match its surface", sentence, token.Text)
+			}
+		}
+	}
+
+	tokens := TokenizeWithOffsets("Ibu  &amp; Ayah di Café")
+	expected := []Token{
+		{Text: "ibu", Surface: "Ibu", Start: 0, End: 3, StartRune: 0, EndRune: 3},
+		{Text: "ayah", Surface: "Ayah", Start: 11, End: 15, StartRune: 11, EndRune: 15, Position: 1},
+		{Text: "di", Surface: "di", Start: 16, End: 18, StartRune: 16, EndRune: 18, Position: 2},
+		{Text: "caf", Surface: "Caf", Start: 19, End: 22, StartRune: 19, EndRune: 22, Position: 3},
+	}
+
+	if !reflect.DeepEqual(tokens, expected) {
+		t.Errorf("expected: %+v\nresult:   %+v", expected, tokens)
+	}
+}
+
+func FuzzTokenizeWithOffsets(f *testing.F) {
+	for _, sentence := range tokenizerTestSentences {
+		f.Add(sentence)
+	}
+
+	// Entities that decoded into several characters
+	f.Add("x &nvlt; y &bne;z &fjlig;")
+
+	tokenizers := []Tokenizer{
+		{Classify: true},
+		{Unicode: true},
+		{Unicode: true, Classify: true},
+		{FoldDiacritics: true, KeepHyphens: true, Classify: true},
+	}
+
+	f.Fuzz(func(t *testing.T, sentence string) {
+		expected := Tokenize(sentence)
+		tokens := TokenizeWithOffsets(sentence)
+		if len(tokens) != len(expected) {
+			t.Fatalf("%q, expected %d tokens, result: %d", sentence, len(expected), len(tokens))
+		}
+
+		lastEnd := 0
+		for i, token := range tokens {
+			if token.Text != expected[i] {
+				t.Errorf("%q, expected token %q, result: %q", sentence, expected[i], token.Text)
+			}
+
+			if token.Start < lastEnd || token.End <= token.Start || sentence[token.Start:token.End] != token.Surface {
+				t.Errorf("%q, invalid offsets for %q: %d-%d", sentence, token.Text, token.Start, token.End)
+			}
+
+			lastEnd = token.End
+		}
+
+		// Tokens of the other tokenizers might share the original text if they're
+		// decoded from the same HTML entity, but they're still sorted
+		for _, tokenizer := range tokenizers {
+			lastStart := 0
+			for _, token := range tokenizer.TokenizeWithOffsets(sentence) {
+				if token.Start < lastStart || token.End <= token.Start || sentence[token.Start:token.End] != token.Surface {
+					t.Errorf("%q, %+v, invalid offsets for %q: %d-%d", sentence, tokenizer, token.Text, token.Start, token.End)
+					continue
+				}
+
+				if token.StartRune != utf8.RuneCountInString(sentence[:token.Start]) ||
+					token.EndRune != utf8.RuneCountInString(sentence[:token.End]) {
+					t.Errorf("%q, %+v, invalid rune offsets for %q: %d-%d", sentence, tokenizer, token.Text, token.StartRune, token.EndRune)
+				}
+
+				lastStart = token.Start
+			}
+		}
+	})
+}
+
+func TestTokenizer(t *testing.T) {
+	for _, sentence := range tokenizerTestSentences {
+		expected := Tokenize(sentence)
+		if result := (Tokenizer{}).Tokenize(sentence); !reflect.DeepEqual(result, expected) {
+			t.Errorf("%q\nexpected: %q\nresult:   %q", sentence, expected, result)
+		}
+	}
+
+	sentence := "Anak-anak di kuasa-Mu membayar Rp 1.500,50 utk covid19 -- info: @kompascom #BeritaHariIni " +
+		"redaksi@kompas.com http://www.kompas.com"
+
+	testItems := []struct {
+		tokenizer Tokenizer
+		expected  []string
+	}{
+		{
+			tokenizer: Tokenizer{KeepHyphens: true},
+			expected:  []string{"anak-anak", "di", "kuasa-mu", "membayar", "rp", "utk", "covid", "info"},
+		},
+		{
+			tokenizer: Tokenizer{KeepNumbers: true},
+			expected:  []string{"anak", "anak", "di", "kuasa", "mu", "membayar", "rp", "1.500,50", "utk", "covid19", "info"},
+		},
+		{
+			tokenizer: Tokenizer{KeepHashtags: true, KeepMentions: true, KeepURLs: true, KeepEmails: true},
+			expected: []string{"anak", "anak", "di", "kuasa", "mu", "membayar", "rp", "utk", "covid", "info",
+				"@kompascom", "#beritahariini", "redaksi@kompas.com", "http://www.kompas.com"},
+		},
+		{
+			tokenizer: Tokenizer{KeepCase: true, KeepHyphens: true, KeepHashtags: true},
+			expected: []string{"Anak-anak", "di", "kuasa-Mu", "membayar", "Rp", "utk", "covid", "info",
+				"#BeritaHariIni"},
+		},
+	}
+
+	for _, item := range testItems {
+		result := item.tokenizer.Tokenize(sentence)
+		if !reflect.DeepEqual(result, item.expected) {
+			t.Errorf("%+v\nexpected: %q\nresult:   %q", item.tokenizer, item.expected, result)
+		}
+	}
+}
+
+func TestTokenizerClassify(t *testing.T) {
+	sentence := "Harga naik 10%! Cek http://kompas.com ; tanya redaksi@kompas.com atau @kompascom #Berita 😂👍🏽🇮🇩 &amp; 👨‍👩‍👧"
+	expected := []string{
+		"harga/WORD", "naik/WORD", "10/NUMBER", "%/PUNCT", "!/PUNCT", "cek/WORD",
+		"http://kompas.com/URL", ";/PUNCT", "tanya/WORD", "redaksi@kompas.com/EMAIL", "atau/WORD",
+		"@kompascom/MENTION", "#berita/HASHTAG", "😂/EMOJI", "👍🏽/EMOJI", "🇮🇩/EMOJI", "&/PUNCT",
+		"👨‍👩‍👧/EMOJI",
+	}
+
+	result := []string{}
+	for _, token := range (Tokenizer{Classify: true}).TokenizeWithOffsets(sentence) {
+		result = append(result, token.Text+"/"+token.Type.String())
+		if !strings.EqualFold(sentence[token.Start:token.End], token.Surface) {
+			t.Errorf("%q: surface %q doesn't match offsets", token.Text, token.Surface)
+		}
+	}
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected: %q\nresult:   %q", expected, result)
+	}
+
+	for _, token := range TokenizeWithOffsets(sentence) {
+		if token.Type != TokenWord {
+			t.Errorf("%q, expected: %s, result: %s", token.Text, TokenWord, token.Type)
+		}
+	}
+}
+
+func TestTokenizerUnicode(t *testing.T) {
+	sentence := "Café Gödel di Jum’at\u00a0pagi, ka\u00adta Shalāḥ ad-Dīn\u200bdan ʿUmar: naïve"
+	testItems := []struct {
+		tokenizer Tokenizer
+		expected  []string
+	}{
+		{
+			tokenizer: Tokenizer{},
+			expected:  []string{"caf", "g", "del", "di", "jum", "at", "pagi", "ka", "ta", "shal", "ad", "d", "n", "dan", "umar", "na", "ve"},
+		},
+		{
+			tokenizer: Tokenizer{Unicode: true},
+			expected:  []string{"café", "gödel", "di", "jum'at", "pagi", "kata", "shalāḥ", "ad", "dīn", "dan", "ʿumar", "naïve"},
+		},
+		{
+			tokenizer: Tokenizer{FoldDiacritics: true, KeepHyphens: true},
+			expected:  []string{"cafe", "godel", "di", "jumat", "pagi", "kata", "shalah", "ad-din", "dan", "umar", "naive"},
+		},
+		{
+			tokenizer: Tokenizer{FoldDiacritics: true, KeepCase: true},
+			expected:  []string{"Cafe", "Godel", "di", "Jumat", "pagi", "kata", "Shalah", "ad", "Din", "dan", "Umar", "naive"},
+		},
+	}
+
+	for _, item := range testItems {
+		tokens := item.tokenizer.TokenizeWithOffsets(sentence)
+		result := []string{}
+		for _, token := range tokens {
+			result = append(result, token.Text)
+			if strings.ContainsAny(token.Surface, " \u00a0\u200b") {
+				t.Errorf("%+v: surface %q contains separator", item.tokenizer, token.Surface)
+			}
+		}
+
+		if !reflect.DeepEqual(result, item.expected) {
+			t.Errorf("%+v\nexpected: %q\nresult:   %q", item.tokenizer, item.expected, result)
+		}
+	}
+
+}
+
+func TestTokenScanner(t *testing.T) {
+	text := strings.Join(tokenizerTestSentences, "\n") + "\r\nTanpa baris baru di akhir &amp; kuasa-Mu"
+	expected := TokenizeWithOffsets(text)
+
+	scanner := NewTokenScanner(iotest.OneByteReader(strings.NewReader(text)))
+	result := []Token{}
+	for scanner.Scan() {
+		result = append(result, scanner.Token())
+	}
+
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("expected: %+v\nresult:   %+v", expected, result)
+	}
+
+	// Long lines are cut at whitespace, so the words stay the same
+	text = strings.Repeat("Pertanyaan yang dijawab oleh pemerintah Indonésia ", 100)
+	scanner = Tokenizer{Unicode: true}.NewScanner(strings.NewReader(text))
+	scanner.Buffer(64)
+	words := []string{}
+	for scanner.Scan() {
+		token := scanner.Token()
+		words = append(words, scanner.Text())
+		if text[token.Start:token.End] != token.Surface {
+			t.Errorf("%q: surface %q doesn't match offsets", token.Text, token.Surface)
+		}
+	}
+
+	if expected := (Tokenizer{Unicode: true}).Tokenize(text); !reflect.DeepEqual(words, expected) {
+		t.Errorf("expected: %q\nresult:   %q", expected, words)
+	}
+
+	scanner = NewTokenScanner(iotest.TimeoutReader(strings.NewReader(text)))
+	for scanner.Scan() {
+	}
+
+	if scanner.Err() != iotest.ErrTimeout {
+		t.Errorf("expected: %v, result: %v", iotest.ErrTimeout, scanner.Err())
+	}
+
+	// Reader that never returns data nor error must not make scanner loop forever
+	scanner = NewTokenScanner(emptyReader{})
+	if scanner.Scan() || scanner.Err() != io.ErrNoProgress {
+		t.Errorf("expected: %v, result: %v", io.ErrNoProgress, scanner.Err())
+	}
+}
+
+// emptyReader is io.Reader that always returns 0, nil
+type emptyReader struct{}
+
+func (emptyReader) Read(p []byte) (int, error) {
+	return 0, nil
+}